@@ -0,0 +1,213 @@
+// Package breaker provides a circuit breaker that trips on classes of errors
+package breaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// Open is the class of errors returned by a Breaker while it is open.
+var Open = errs.Class("breaker open")
+
+// State is the state of a Breaker.
+type State int
+
+// The states of a Breaker.
+const (
+	// StateClosed lets every call through.
+	StateClosed State = iota
+
+	// StateOpen fails every call until the cooldown has passed.
+	StateOpen
+
+	// StateHalfOpen lets a single trial call through to decide if the
+	// breaker should close again.
+	StateHalfOpen
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Clock tells the time. It can be replaced in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the Clock that uses the time package.
+type realClock struct{}
+
+// Now calls time.Now.
+func (realClock) Now() time.Time { return time.Now() }
+
+// Config configures a Breaker. The zero value of any field uses the default for
+// that field.
+type Config struct {
+	// Classes are the classes of errors that count as failures. Errors in no
+	// class, like errors caused by invalid arguments from the caller, are
+	// treated as successes since the call got a response.
+	Classes []*errs.Class
+
+	// Threshold is the number of consecutive failures that opens the
+	// breaker. The default is 5.
+	Threshold int
+
+	// Cooldown is how long the breaker stays open before letting a trial
+	// call through. The default is 30 seconds.
+	Cooldown time.Duration
+
+	// OnStateChange is called after every change of state. It is not called
+	// with any locks held, so it may call methods on the Breaker.
+	OnStateChange func(from, to State)
+
+	// Clock tells the time. The default uses the time package.
+	Clock Clock
+}
+
+// Breaker is a circuit breaker. It fails calls quickly after the calls it
+// protects have failed too many times in a row, and lets them through again
+// after a cooldown once a trial call succeeds.
+type Breaker struct {
+	config Config
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+	last     error
+}
+
+// New constructs a closed Breaker with the configuration.
+func New(config Config) *Breaker {
+	if config.Threshold <= 0 {
+		config.Threshold = 5
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 30 * time.Second
+	}
+	if config.Clock == nil {
+		config.Clock = realClock{}
+	}
+	return &Breaker{config: config}
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.cooledDown() {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Do calls fn if the breaker allows it and returns its error. If the breaker
+// does not allow it, it returns an error in the Open class that wraps the last
+// failure. If fn panics, the call counts as a failure and the panic continues.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	returned := false
+	defer func() {
+		if !returned {
+			b.record(errs.New("call panicked"), true)
+		}
+	}()
+
+	err := fn()
+	returned = true
+	b.record(err, b.isFailure(err))
+	return err
+}
+
+// allow returns an error if the call is not allowed, and otherwise marks the
+// call as the trial if the breaker is half-open.
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+
+	from := b.state
+	if b.state == StateOpen && b.cooledDown() {
+		b.state = StateHalfOpen
+	}
+
+	var err error
+	switch {
+	case b.state == StateOpen, b.state == StateHalfOpen && b.trial:
+		err = Open.NewDepth(2, "last failure: %w", b.last)
+	case b.state == StateHalfOpen:
+		b.trial = true
+	}
+
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+	return err
+}
+
+// record updates the state of the breaker with the result of a call, which
+// is a failure if failed is true.
+func (b *Breaker) record(err error, failed bool) {
+	b.mu.Lock()
+
+	from := b.state
+	if failed {
+		b.failures++
+		b.last = err
+		if b.state == StateHalfOpen || b.failures >= b.config.Threshold {
+			b.state = StateOpen
+			b.openedAt = b.config.Clock.Now()
+		}
+	} else {
+		b.failures = 0
+		b.state = StateClosed
+	}
+	b.trial = false
+
+	to := b.state
+	b.mu.Unlock()
+
+	b.changed(from, to)
+}
+
+// isFailure returns true if the error is in any of the configured classes.
+func (b *Breaker) isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, class := range b.config.Classes {
+		if class.Has(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// cooledDown returns true if the breaker has been open for the cooldown. It
+// must be called with the mutex held.
+func (b *Breaker) cooledDown() bool {
+	return b.config.Clock.Now().Sub(b.openedAt) >= b.config.Cooldown
+}
+
+// changed calls the callback if the state changed.
+func (b *Breaker) changed(from, to State) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
@@ -0,0 +1,131 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// fakeClock is a Clock that only moves when told to.
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestBreaker(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+
+	var transitions []string
+	b := New(Config{
+		Classes:   []*errs.Class{&errs.Unavailable},
+		Threshold: 2,
+		Cooldown:  time.Minute,
+		Clock:     clock,
+		OnStateChange: func(from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	unavailable := errs.Unavailable.New("down")
+	invalid := errs.InvalidArgument.New("bad")
+	fail := func() error { return unavailable }
+	succeed := func() error { return nil }
+
+	var calls int
+	counted := func() error { calls++; return nil }
+
+	// caller errors never trip the breaker.
+	for i := 0; i < 5; i++ {
+		assert(t, b.Do(func() error { return invalid }) == invalid)
+	}
+	assert(t, b.State() == StateClosed)
+
+	// consecutive failures trip it.
+	assert(t, b.Do(fail) == unavailable)
+	assert(t, b.Do(succeed) == nil)
+	assert(t, b.Do(fail) == unavailable)
+	assert(t, b.State() == StateClosed)
+	assert(t, b.Do(fail) == unavailable)
+	assert(t, b.State() == StateOpen)
+
+	// open breakers fail fast with the last failure as the cause.
+	err := b.Do(counted)
+	assert(t, calls == 0)
+	assert(t, Open.Has(err), err)
+	assert(t, errs.Unavailable.Has(err))
+	assert(t, errors.Is(err, unavailable))
+	assert(t, err.Error() == "breaker open: last failure: unavailable: down", err)
+
+	// after the cooldown a failed trial opens it again.
+	clock.now = clock.now.Add(time.Minute)
+	assert(t, b.State() == StateHalfOpen)
+	assert(t, b.Do(fail) == unavailable)
+	assert(t, b.State() == StateOpen)
+	assert(t, Open.Has(b.Do(counted)))
+
+	// and a successful trial closes it.
+	clock.now = clock.now.Add(time.Minute)
+	assert(t, b.Do(counted) == nil)
+	assert(t, calls == 1)
+	assert(t, b.State() == StateClosed)
+
+	assert(t, len(transitions) == 5, transitions)
+	assert(t, transitions[0] == "closed->open", transitions)
+	assert(t, transitions[1] == "open->half-open", transitions)
+	assert(t, transitions[2] == "half-open->open", transitions)
+	assert(t, transitions[3] == "open->half-open", transitions)
+	assert(t, transitions[4] == "half-open->closed", transitions)
+}
+
+func TestBreakerTrial(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := New(Config{Classes: []*errs.Class{&errs.Unavailable}, Threshold: 1, Clock: clock})
+
+	_ = b.Do(func() error { return errs.Unavailable.New("down") })
+	clock.now = clock.now.Add(time.Hour)
+
+	// only a single trial is let through while half-open.
+	err := b.Do(func() error {
+		if err := b.Do(func() error { return nil }); !Open.Has(err) {
+			t.Fatal("expected concurrent call during trial to fail, got", err)
+		}
+		return nil
+	})
+	if err != nil || b.State() != StateClosed {
+		t.Fatal("expected trial to close breaker", err, b.State())
+	}
+}
+
+func TestBreakerPanic(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := New(Config{Classes: []*errs.Class{&errs.Unavailable}, Threshold: 1, Clock: clock})
+
+	_ = b.Do(func() error { return errs.Unavailable.New("down") })
+	clock.now = clock.now.Add(time.Hour)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the panic to continue")
+			}
+		}()
+		_ = b.Do(func() error { panic("boom") })
+	}()
+
+	// the panicking trial counts as a failure instead of blocking every call.
+	if b.State() != StateOpen {
+		t.Fatal("expected panicking trial to open breaker", b.State())
+	}
+	clock.now = clock.now.Add(time.Hour)
+	if err := b.Do(func() error { return nil }); err != nil || b.State() != StateClosed {
+		t.Fatal("expected trial to close breaker", err, b.State())
+	}
+}
@@ -0,0 +1,98 @@
+package errs
+
+import (
+	"context"
+	"os"
+)
+
+// The canonical classes. They describe common kinds of failures in a way that
+// can be mapped to protocols like HTTP or gRPC. Canonical maps well known
+// errors from the standard library to them. They are not registered, so that
+// packages remain free to register their own classes with the same names.
+var (
+	NotFound          = Class("not found")
+	AlreadyExists     = Class("already exists")
+	PermissionDenied  = Class("permission denied")
+	InvalidArgument   = Class("invalid argument")
+	Unavailable       = Class("unavailable")
+	DeadlineExceeded  = Class("deadline exceeded")
+	Canceled          = Class("canceled")
+	Internal          = Class("internal")
+	Unimplemented     = Class("unimplemented")
+	ResourceExhausted = Class("resource exhausted")
+)
+
+// canonicalClasses is the set of canonical classes.
+var canonicalClasses = map[*Class]bool{
+	&NotFound:          true,
+	&AlreadyExists:     true,
+	&PermissionDenied:  true,
+	&InvalidArgument:   true,
+	&Unavailable:       true,
+	&DeadlineExceeded:  true,
+	&Canceled:          true,
+	&Internal:          true,
+	&Unimplemented:     true,
+	&ResourceExhausted: true,
+}
+
+// canonicalSentinels maps sentinel errors from the standard library to the
+// canonical classes. It is a slice rather than a map because hashing an
+// arbitrary error panics if its type is not comparable, while comparing it to
+// a comparable sentinel does not.
+var canonicalSentinels = []struct {
+	sentinel error
+	class    *Class
+}{
+	{os.ErrNotExist, &NotFound},
+	{os.ErrExist, &AlreadyExists},
+	{os.ErrPermission, &PermissionDenied},
+	{os.ErrInvalid, &InvalidArgument},
+	{context.Canceled, &Canceled},
+	{context.DeadlineExceeded, &DeadlineExceeded},
+}
+
+// Canonical returns the canonical class of the error. If the error has been
+// wrapped by any canonical class, the outermost one is returned. Otherwise, it
+// maps well known errors from the standard library: fs.ErrNotExist,
+// fs.ErrExist, fs.ErrPermission, fs.ErrInvalid, context.Canceled,
+// context.DeadlineExceeded, syscall.Errno values and errors like net.Error
+// that report a timeout. It returns nil if there is no canonical class.
+func Canonical(err error) (class *Class) {
+	if err == nil {
+		return nil
+	}
+
+	IsFunc(err, func(err error) bool {
+		if e, ok := asErrorT(err); ok && canonicalClasses[e.class] {
+			class = e.class
+		}
+		return class != nil
+	})
+	if class != nil {
+		return class
+	}
+
+	IsFunc(err, func(err error) bool {
+		class = canonicalStdlib(err)
+		return class != nil
+	})
+	return class
+}
+
+// canonicalStdlib returns the canonical class for a single error from the
+// standard library, without looking at any errors it wraps.
+func canonicalStdlib(err error) *Class {
+	for _, entry := range canonicalSentinels {
+		if err == entry.sentinel {
+			return entry.class
+		}
+	}
+	if class := canonicalErrno(err); class != nil {
+		return class
+	}
+	if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+		return &DeadlineExceeded
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+//go:build !plan9
+// +build !plan9
+
+package errs
+
+import "syscall"
+
+// canonicalErrnos maps system call errors to the canonical classes.
+var canonicalErrnos = map[syscall.Errno]*Class{
+	syscall.ENOENT:       &NotFound,
+	syscall.EEXIST:       &AlreadyExists,
+	syscall.EACCES:       &PermissionDenied,
+	syscall.EPERM:        &PermissionDenied,
+	syscall.EINVAL:       &InvalidArgument,
+	syscall.ECONNREFUSED: &Unavailable,
+	syscall.ECONNRESET:   &Unavailable,
+	syscall.EAGAIN:       &Unavailable,
+	syscall.ETIMEDOUT:    &DeadlineExceeded,
+	syscall.ENOSYS:       &Unimplemented,
+	syscall.ENOTSUP:      &Unimplemented,
+	syscall.ENOSPC:       &ResourceExhausted,
+	syscall.EMFILE:       &ResourceExhausted,
+	syscall.ENFILE:       &ResourceExhausted,
+	syscall.ENOMEM:       &ResourceExhausted,
+}
+
+// canonicalErrno returns the canonical class of the error if it is a
+// syscall.Errno.
+func canonicalErrno(err error) *Class {
+	if errno, ok := err.(syscall.Errno); ok {
+		return canonicalErrnos[errno]
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build !plan9
+// +build !plan9
+
+package errs
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestCanonicalErrno(t *testing.T) {
+	cases := map[syscall.Errno]*Class{
+		syscall.ENOENT:    &NotFound,
+		syscall.EACCES:    &PermissionDenied,
+		syscall.ETIMEDOUT: &DeadlineExceeded,
+		syscall.ENOSPC:    &ResourceExhausted,
+	}
+	for errno, class := range cases {
+		if got := Canonical(New("wrapped: %w", errno)); got != class {
+			t.Fatal("wrong class for", errno, got)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+//go:build plan9
+// +build plan9
+
+package errs
+
+// canonicalErrno returns nil because plan9 does not have syscall.Errno.
+func canonicalErrno(err error) *Class { return nil }
@@ -0,0 +1,62 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestCanonical(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+
+	t.Run("Explicit", func(t *testing.T) {
+		assert(t, Canonical(nil) == nil)
+		assert(t, Canonical(errors.New("t")) == nil)
+		assert(t, Canonical(foo.New("t")) == nil)
+		assert(t, Canonical(NotFound.New("t")) == &NotFound)
+		assert(t, Canonical(foo.Wrap(Unavailable.New("t"))) == &Unavailable)
+		assert(t, Canonical(Internal.Wrap(Unavailable.New("t"))) == &Internal)
+		assert(t, Canonical(Internal.Wrap(context.Canceled)) == &Internal)
+	})
+
+	t.Run("Not Registered", func(t *testing.T) {
+		_, ok := LookupClass("not found")
+		assert(t, !ok)
+
+		class := Class("not found")
+		assert(t, Canonical(class.New("t")) == nil)
+	})
+
+	t.Run("Stdlib", func(t *testing.T) {
+		_, err := os.Open("/this/path/does/not/exist")
+		assert(t, Canonical(err) == &NotFound, err)
+		assert(t, Canonical(foo.Wrap(err)) == &NotFound, err)
+
+		assert(t, Canonical(os.ErrExist) == &AlreadyExists)
+		assert(t, Canonical(os.ErrPermission) == &PermissionDenied)
+		assert(t, Canonical(context.Canceled) == &Canceled)
+		assert(t, Canonical(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)) == &DeadlineExceeded)
+		assert(t, Canonical(foo.Wrap(timeoutError{})) == &DeadlineExceeded)
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		assert(t, Canonical(Combine(errors.New("a"), errors.New("b"))) == nil)
+		assert(t, Canonical(Combine(errors.New("a"), os.ErrNotExist)) == &NotFound)
+		assert(t, Canonical(foo.Wrap(Combine(errors.New("a"), errors.New("b")))) == nil)
+	})
+}
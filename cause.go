@@ -0,0 +1,14 @@
+package errs
+
+// causeError is the error of a context ending whose message is replaced by
+// the cause recorded for the context.
+type causeError struct {
+	err   error
+	cause error
+}
+
+// Error returns the message of the cause.
+func (e *causeError) Error() string { return e.cause.Error() }
+
+// Unwrap returns both the error and the cause.
+func (e *causeError) Unwrap() []error { return []error{e.err, e.cause} }
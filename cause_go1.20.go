@@ -0,0 +1,37 @@
+//go:build go1.20
+
+package errs
+
+import (
+	"context"
+	"errors"
+)
+
+// Cancel cancels the context with an error in this class as the cause, so that
+// context.Cause and WrapCtxErr report why the context ended. If err is nil,
+// the cause is a new error in this class wrapping context.Canceled. The stack
+// trace of the cause starts at the caller of Cancel.
+func (c *Class) Cancel(cancel context.CancelCauseFunc, err error) {
+	if err == nil {
+		err = context.Canceled
+	}
+	cancel(c.create(3, err, nil))
+}
+
+// WrapCtxErr is like WrapCtx, except that if err is the bare error of the
+// context ending, like context.Canceled or context.DeadlineExceeded, and the
+// context recorded a different cause with context.WithCancelCause or similar,
+// the cause replaces it in the message. The result still matches the original
+// error with errors.Is, as well as the cause.
+func (c *Class) WrapCtxErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil && err != nil && err == ctxErr {
+		if cause := context.Cause(ctx); cause != nil && cause != ctxErr {
+			if errors.Is(cause, ctxErr) {
+				err = cause
+			} else {
+				err = &causeError{err: err, cause: cause}
+			}
+		}
+	}
+	return c.create(3, err, contextFields(ctx))
+}
@@ -0,0 +1,86 @@
+//go:build go1.20
+
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCause(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	shutdown := Class("shutdown")
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		shutdown.Cancel(cancel, errors.New("server stopping"))
+
+		cause := context.Cause(ctx)
+		assert(t, shutdown.Has(cause))
+		assert(t, cause.Error() == "shutdown: server stopping", cause.Error())
+
+		err := foo.WrapCtxErr(ctx, ctx.Err())
+		assert(t, err.Error() == "foo: shutdown: server stopping", err.Error())
+		assert(t, errors.Is(err, context.Canceled))
+		assert(t, errors.Is(err, cause))
+		assert(t, foo.Has(err) && shutdown.Has(err))
+	})
+
+	t.Run("Cancel Nil", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		shutdown.Cancel(cancel, nil)
+
+		assert(t, shutdown.Has(context.Cause(ctx)))
+		assert(t, errors.Is(context.Cause(ctx), context.Canceled))
+
+		err := foo.WrapCtxErr(ctx, ctx.Err())
+		assert(t, err.Error() == "foo: shutdown: context canceled", err.Error())
+		assert(t, errors.Is(err, context.Canceled))
+		assert(t, foo.Has(err) && shutdown.Has(err))
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		shutdown.Cancel(cancel, errors.New("server stopping"))
+
+		err := foo.WrapCtxErr(ctx, fmt.Errorf("read: %w", ctx.Err()))
+		assert(t, err.Error() == "foo: read: context canceled", err.Error())
+	})
+
+	t.Run("Helpers", func(t *testing.T) {
+		stopped := Class("stopped")
+		stopped.SetCatalog("de", Catalog{Name: "gestoppt", Messages: map[string]string{"server %v": "Server %v"}})
+
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(stopped.New("server %v", Secret("main")))
+
+		err := foo.WrapCtxErr(ctx, ctx.Err())
+		assert(t, err.Error() == "foo: stopped: server [REDACTED]", err.Error())
+		assert(t, Unredacted(err) == "foo: stopped: server main", Unredacted(err))
+		assert(t, Localize(err, "de") == "foo: gestoppt: Server [REDACTED]", Localize(err, "de"))
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := foo.WrapCtxErr(ctx, ctx.Err())
+		assert(t, err.Error() == "foo: context canceled", err.Error())
+
+		other := errors.New("other")
+		err = foo.WrapCtxErr(ctx, other)
+		assert(t, err.Error() == "foo: other", err.Error())
+
+		assert(t, foo.WrapCtxErr(ctx, nil) == nil)
+		assert(t, foo.WrapCtxErr(context.Background(), other).Error() == "foo: other")
+	})
+}
@@ -0,0 +1,106 @@
+package errs
+
+import (
+	"reflect"
+	"regexp"
+)
+
+// Classifier assigns classes to errors that do not have one, like errors
+// returned from third party libraries, using a list of rules. The zero value
+// has no rules. Rules must not be added concurrently with calls to Wrap.
+type Classifier struct {
+	rules []classifierRule
+}
+
+// classifierRule assigns the class to errors that match.
+type classifierRule struct {
+	match func(err error) bool
+	class *Class
+}
+
+// Match adds a rule assigning the class to errors for which fn returns true.
+func (c *Classifier) Match(fn func(err error) bool, class *Class) {
+	c.rules = append(c.rules, classifierRule{match: fn, class: class})
+}
+
+// Is adds a rule assigning the class to errors that are or wrap the target,
+// as determined by Is.
+func (c *Classifier) Is(target error, class *Class) {
+	c.Match(func(err error) bool { return Is(err, target) }, class)
+}
+
+// As adds a rule assigning the class to errors that are or wrap an error of
+// the type pointed to by target, in the same way as errors.As. For example,
+// to match any *fs.PathError:
+//
+//	classifier.As(new(*fs.PathError), &NotFound)
+//
+// As panics if target is not a non-nil pointer to an interface or a type
+// implementing error.
+func (c *Classifier) As(target interface{}, class *Class) {
+	typ := reflect.TypeOf(target)
+	if typ == nil || typ.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() {
+		panic("errs: Classifier.As target must be a non-nil pointer")
+	}
+	typ = typ.Elem()
+	if typ.Kind() != reflect.Interface && !typ.Implements(errorType) {
+		panic("errs: Classifier.As target must point to an interface or a type implementing error")
+	}
+
+	c.Match(func(err error) bool {
+		return IsFunc(err, func(err error) bool {
+			if err == nil {
+				return false
+			}
+			if reflect.TypeOf(err).AssignableTo(typ) {
+				return true
+			}
+			x, ok := err.(interface{ As(interface{}) bool })
+			return ok && x.As(reflect.New(typ).Interface())
+		})
+	}, class)
+}
+
+// errorType is the reflect.Type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Regexp adds a rule assigning the class to errors whose message matches re.
+func (c *Classifier) Regexp(re *regexp.Regexp, class *Class) {
+	c.Match(func(err error) bool { return re.MatchString(err.Error()) }, class)
+}
+
+// Classify returns the class assigned by the first matching rule, or nil if no
+// rule matches. It does not look at any classes the error already has.
+func (c *Classifier) Classify(err error) *Class {
+	if err == nil {
+		return nil
+	}
+	for _, rule := range c.rules {
+		if rule.match(err) {
+			return rule.class
+		}
+	}
+	return nil
+}
+
+// Wrap returns the error wrapped with the class of the first matching rule. If
+// the error already has a class or no rule matches, the error is returned
+// unchanged. Wrap returns nil if err is nil.
+func (c *Classifier) Wrap(err error) error {
+	if err == nil || hasClass(err) {
+		return err
+	}
+	class := c.Classify(err)
+	if class == nil {
+		return err
+	}
+	return class.create(3, err, nil)
+}
+
+// hasClass returns true if the error or any error it wraps has a class.
+func hasClass(err error) bool {
+	return IsFunc(err, func(err error) bool {
+		e, ok := asErrorT(err)
+		return ok && e.class != nil
+	})
+}
@@ -0,0 +1,70 @@
+package errs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type codeError struct{ code int }
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func TestClassifier(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+
+	var c Classifier
+	c.Is(io.EOF, &NotFound)
+	c.As(new(*codeError), &Unavailable)
+	c.As(new(interface{ Timeout() bool }), &DeadlineExceeded)
+	c.Match(func(err error) bool { return err == context.Canceled }, &Canceled)
+	c.Regexp(regexp.MustCompile(`^quota`), &ResourceExhausted)
+	c.Is(io.ErrUnexpectedEOF, &Internal)
+
+	t.Run("Rules", func(t *testing.T) {
+		assert(t, c.Wrap(nil) == nil)
+		assert(t, NotFound.Has(c.Wrap(io.EOF)))
+		assert(t, NotFound.Has(c.Wrap(fmt.Errorf("read: %w", io.EOF))))
+		assert(t, Unavailable.Has(c.Wrap(&codeError{code: 1})))
+		assert(t, Unavailable.Has(c.Wrap(fmt.Errorf("call: %w", &codeError{code: 1}))))
+		assert(t, DeadlineExceeded.Has(c.Wrap(timeoutError{})))
+		assert(t, Canceled.Has(c.Wrap(context.Canceled)))
+		assert(t, ResourceExhausted.Has(c.Wrap(fmt.Errorf("quota exceeded"))))
+	})
+
+	t.Run("First Match", func(t *testing.T) {
+		err := c.Wrap(Combine(io.ErrUnexpectedEOF, io.EOF))
+		assert(t, NotFound.Has(err))
+		assert(t, !Internal.Has(err))
+	})
+
+	t.Run("Stack", func(t *testing.T) {
+		err := c.Wrap(io.EOF)
+		assert(t, strings.Contains(fmt.Sprintf("%+v", err), "TestClassifier"), fmt.Sprintf("%+v", err))
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		err := foo.Wrap(io.EOF)
+		assert(t, c.Wrap(err) == err)
+
+		err = os.ErrClosed
+		assert(t, c.Wrap(err) == err)
+		assert(t, c.Classify(err) == nil)
+	})
+
+	t.Run("Invalid As", func(t *testing.T) {
+		defer func() { assert(t, recover() != nil, "expected panic") }()
+		c.As(codeError{}, &Internal)
+	})
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// errsPath is the import path of the errs package.
+const errsPath = "github.com/zeebo/errs"
+
+// diagnostic is a problem found at some position.
+type diagnostic struct {
+	pos     token.Position
+	message string
+}
+
+// String formats the diagnostic like the compiler formats errors.
+func (d diagnostic) String() string { return fmt.Sprintf("%s: %s", d.pos, d.message) }
+
+// checker holds the state for checking a single package.
+type checker struct {
+	fset  *token.FileSet
+	pkg   *types.Package
+	info  *types.Info
+	diags []diagnostic
+}
+
+// check returns the diagnostics for the type checked files of the package.
+func check(fset *token.FileSet, files []*ast.File, pkg *types.Package, info *types.Info) []diagnostic {
+	if pkg != nil && pkg.Path() == errsPath {
+		return nil
+	}
+
+	c := &checker{fset: fset, pkg: pkg, info: info}
+	classes := declaresClass(pkg)
+
+	for _, file := range files {
+		targets := c.isTargets(file)
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				c.checkNew(n)
+				if c.isErrs(c.callee(n), "Instance", true) && !targets[n] {
+					c.report(n.Pos(), "Class.Instance() used as a concrete error: "+
+						"it panics if formatted and should only be the target of errors.Is")
+				}
+
+			case *ast.Ident:
+				obj := c.info.Uses[n]
+				if c.isErrs(obj, "Unwrap", false) {
+					c.report(n.Pos(), "errs.Unwrap is deprecated: use errors.Is or errors.As")
+				}
+				if c.isErrs(obj, "Causer", false) {
+					c.report(n.Pos(), "errs.Causer is deprecated: use the Unwrap method")
+				}
+
+			case *ast.FuncDecl:
+				if classes && n.Body != nil {
+					if fn, ok := c.info.Defs[n.Name].(*types.Func); ok {
+						c.checkReturns(fn.Type().(*types.Signature), n.Body)
+					}
+				}
+
+			case *ast.FuncLit:
+				if classes {
+					if sig, ok := c.info.Types[n].Type.(*types.Signature); ok {
+						c.checkReturns(sig, n.Body)
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	sort.Slice(c.diags, func(i, j int) bool {
+		pi, pj := c.diags[i].pos, c.diags[j].pos
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		return pi.Column < pj.Column
+	})
+	return c.diags
+}
+
+// report adds a diagnostic at the position.
+func (c *checker) report(pos token.Pos, message string) {
+	c.diags = append(c.diags, diagnostic{pos: c.fset.Position(pos), message: message})
+}
+
+// declaresClass returns true if the package has a package level variable that
+// is an errs.Class or a pointer to one.
+func declaresClass(pkg *types.Package) bool {
+	if pkg == nil {
+		return false
+	}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		v, ok := scope.Lookup(name).(*types.Var)
+		if !ok {
+			continue
+		}
+		typ := v.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if named, ok := typ.(*types.Named); ok && isErrsObject(named.Obj(), "Class") {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrsObject returns true if the object is the named member of the errs
+// package.
+func isErrsObject(obj types.Object, name string) bool {
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == errsPath && obj.Name() == name
+}
+
+// isErrs returns true if the object is the named function or type of the errs
+// package, or the named method of one of its types if method is true.
+func (c *checker) isErrs(obj types.Object, name string, method bool) bool {
+	if !isErrsObject(obj, name) {
+		return false
+	}
+	if fn, ok := obj.(*types.Func); ok {
+		return (fn.Type().(*types.Signature).Recv() != nil) == method
+	}
+	return !method
+}
+
+// callee returns the object for the function called, if known.
+func (c *checker) callee(call *ast.CallExpr) types.Object {
+	switch fun := unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return c.info.Uses[fun]
+	case *ast.SelectorExpr:
+		return c.info.Uses[fun.Sel]
+	}
+	return nil
+}
+
+// isTargets returns the calls that are passed as the target argument of
+// errors.Is or errs.Is.
+func (c *checker) isTargets(file *ast.File) map[*ast.CallExpr]bool {
+	targets := make(map[*ast.CallExpr]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		fn, ok := c.callee(call).(*types.Func)
+		if !ok || fn.Name() != "Is" || fn.Pkg() == nil {
+			return true
+		}
+		if path := fn.Pkg().Path(); path != "errors" && path != errsPath {
+			return true
+		}
+		if target, ok := unparen(call.Args[1]).(*ast.CallExpr); ok {
+			targets[target] = true
+		}
+		return true
+	})
+	return targets
+}
+
+// errorType is the error type, and errorIface is its underlying interface.
+var (
+	errorType  = types.Universe.Lookup("error").Type()
+	errorIface = errorType.Underlying().(*types.Interface)
+)
+
+// unparen returns the expression with any enclosing parentheses removed.
+func unparen(expr ast.Expr) ast.Expr {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = paren.X
+	}
+}
+
+// isError returns true if the expression is known to implement error.
+func (c *checker) isError(expr ast.Expr) bool {
+	tv, ok := c.info.Types[expr]
+	return ok && tv.Type != nil && !tv.IsNil() && types.Implements(tv.Type, errorIface)
+}
+
+// checkNew reports errors formatted with %v or %s by errs.New or
+// (*errs.Class).New.
+func (c *checker) checkNew(call *ast.CallExpr) {
+	obj := c.callee(call)
+	if !c.isErrs(obj, "New", false) && !c.isErrs(obj, "New", true) {
+		return
+	}
+	if len(call.Args) == 0 || call.Ellipsis.IsValid() {
+		return
+	}
+	tv, ok := c.info.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+
+	args := call.Args[1:]
+	for i, verb := range verbs(constant.StringVal(tv.Value)) {
+		if i >= len(args) {
+			return
+		}
+		if (verb == 'v' || verb == 's') && c.isError(args[i]) {
+			c.report(args[i].Pos(), fmt.Sprintf("error formatted with %%%c in New: "+
+				"use Wrap or %%w to keep it in the chain", verb))
+		}
+	}
+}
+
+// verbs returns the verb for each argument consumed by the format string. A
+// '*' is returned for arguments consumed as a width or precision. It returns
+// nil if the format string uses explicit argument indexes.
+func verbs(format string) (out []rune) {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		for i++; i < len(format) && strings.IndexByte("+-# 0123456789.*", format[i]) >= 0; i++ {
+			if format[i] == '*' {
+				out = append(out, '*')
+			}
+		}
+		if i >= len(format) {
+			break
+		}
+		switch format[i] {
+		case '[':
+			return nil
+		case '%':
+		default:
+			out = append(out, rune(format[i]))
+		}
+	}
+	return out
+}
+
+// checkReturns reports errors checked against nil and then returned as is
+// from functions that return an error. Function literals are skipped because
+// they are checked on their own.
+func (c *checker) checkReturns(sig *types.Signature, body *ast.BlockStmt) {
+	results := sig.Results()
+	if results.Len() == 0 || !types.Identical(results.At(results.Len()-1).Type(), errorType) {
+		return
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.IfStmt:
+			if obj := c.checkedErr(n.Cond); obj != nil && !c.fromPackage(obj, body) {
+				c.checkReturned(obj, n.Body)
+			}
+		}
+		return true
+	})
+}
+
+// checkedErr returns the variable compared in a condition of the form
+// "err != nil", if it is an error.
+func (c *checker) checkedErr(cond ast.Expr) types.Object {
+	bin, ok := unparen(cond).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.NEQ {
+		return nil
+	}
+	ident, ok := unparen(bin.X).(*ast.Ident)
+	if !ok || !c.isError(ident) {
+		return nil
+	}
+	if nilIdent, ok := unparen(bin.Y).(*ast.Ident); !ok || nilIdent.Name != "nil" {
+		return nil
+	}
+	return c.info.Uses[ident]
+}
+
+// fromPackage returns true if the variable is only assigned in the body the
+// results of calls to functions of the checked package or of errs, which are
+// expected to return errors that already have a class.
+func (c *checker) fromPackage(obj types.Object, body *ast.BlockStmt) bool {
+	found, trusted := false, true
+	ast.Inspect(body, func(n ast.Node) bool {
+		var lhs, rhs []ast.Expr
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			lhs, rhs = n.Lhs, n.Rhs
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				lhs = append(lhs, name)
+			}
+			rhs = n.Values
+		default:
+			return true
+		}
+
+		for i, expr := range lhs {
+			ident, ok := expr.(*ast.Ident)
+			if !ok || (c.info.Defs[ident] != obj && c.info.Uses[ident] != obj) {
+				continue
+			}
+			found = true
+
+			var value ast.Expr
+			if len(rhs) == len(lhs) {
+				value = rhs[i]
+			} else if len(rhs) == 1 {
+				value = rhs[0]
+			}
+			call, ok := value.(*ast.CallExpr)
+			if !ok || !c.inPackage(call) {
+				trusted = false
+			}
+		}
+		return true
+	})
+	return found && trusted
+}
+
+// inPackage returns true if the call is to a function or method of the checked
+// package or of errs.
+func (c *checker) inPackage(call *ast.CallExpr) bool {
+	fn, ok := c.callee(call).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	return fn.Pkg() == c.pkg || fn.Pkg().Path() == errsPath
+}
+
+// checkReturned reports return statements in the block that return the
+// variable unchanged.
+func (c *checker) checkReturned(obj types.Object, block *ast.BlockStmt) {
+	ast.Inspect(block, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if ident, ok := unparen(result).(*ast.Ident); ok && c.info.Uses[ident] == obj {
+					c.report(ident.Pos(), "error returned without being wrapped by a class")
+				}
+			}
+		}
+		return true
+	})
+}
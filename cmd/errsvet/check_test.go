@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// wantRegexp matches the expectations written in the testdata.
+var wantRegexp = regexp.MustCompile(`// want "((?:[^"\\]|\\.)*)"`)
+
+func TestCheck(t *testing.T) {
+	dir := filepath.Join("testdata", "a")
+
+	diags, err := checkDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type want struct {
+		re      *regexp.Regexp
+		matched bool
+	}
+	wants := make(map[int]*want)
+
+	fh, err := os.Open(filepath.Join(dir, "a.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fh.Close()
+
+	scanner := bufio.NewScanner(fh)
+	for line := 1; scanner.Scan(); line++ {
+		match := wantRegexp.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		pattern, err := strconv.Unquote(`"` + match[1] + `"`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wants[line] = &want{re: regexp.MustCompile(pattern)}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diag := range diags {
+		t.Log(diag)
+		w, ok := wants[diag.pos.Line]
+		if !ok || !w.re.MatchString(diag.message) {
+			t.Errorf("unexpected diagnostic: %v", diag)
+			continue
+		}
+		w.matched = true
+	}
+	for line, w := range wants {
+		if !w.matched {
+			t.Errorf("%d: missing diagnostic matching %q", line, w.re)
+		}
+	}
+}
+
+func TestVerbs(t *testing.T) {
+	cases := map[string]string{
+		"":               "",
+		"%v":             "v",
+		"100%% %s %d":    "sd",
+		"%+v %#x %-5.2f": "vxf",
+		"%*d %.*s":       "*d*s",
+		"%[1]v":          "",
+		"trailing %":     "",
+	}
+	for format, expected := range cases {
+		if got := string(verbs(format)); got != expected {
+			t.Errorf("verbs(%q) = %q, expected %q", format, got, expected)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Command errsvet reports likely misuse of the github.com/zeebo/errs package.
+//
+// Usage:
+//
+//	errsvet [directory ...]
+//
+// Each directory is loaded as a package. A directory ending in "/..." also
+// loads every directory below it. With no arguments, the current directory
+// is checked. It reports:
+//
+//   - errors returned unwrapped from "if err != nil" blocks in packages that
+//     declare a Class
+//   - errors formatted with %v or %s by New instead of being wrapped with Wrap
+//   - (*Class).Instance used anywhere other than as the target of Is, since
+//     calling Error on it panics
+//   - uses of the deprecated Unwrap function and Causer interface
+//
+// The exit status is 1 if anything was reported and 2 if a package could not
+// be loaded.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	dirs, err := expand(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "errsvet:", err)
+		os.Exit(2)
+	}
+
+	exit := 0
+	for _, dir := range dirs {
+		diags, err := checkDir(dir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "errsvet:", err)
+			exit = 2
+			continue
+		}
+		for _, diag := range diags {
+			fmt.Println(diag)
+			if exit == 0 {
+				exit = 1
+			}
+		}
+	}
+	os.Exit(exit)
+}
+
+// expand turns the arguments into a list of directories, walking any that end
+// in "/...".
+func expand(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	var dirs []string
+	for _, arg := range args {
+		if arg != "..." && !strings.HasSuffix(arg, "/...") {
+			dirs = append(dirs, arg)
+			continue
+		}
+
+		root := strings.TrimSuffix(strings.TrimSuffix(arg, "..."), "/")
+		if root == "" {
+			root = "."
+		}
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil || !info.IsDir() {
+				return err
+			}
+			name := info.Name()
+			if path != root && (name == "testdata" || name == "vendor" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+// checkDir loads and type checks the package in the directory and returns the
+// diagnostics for it. Directories without Go files are skipped.
+func checkDir(dir string) ([]diagnostic, error) {
+	bpkg, err := build.ImportDir(dir, 0)
+	if _, ok := err.(*build.NoGoError); ok {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range bpkg.GoFiles {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	// type errors are ignored: the checks only need the information that
+	// could be determined, and the compiler reports the errors better.
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	pkg, _ := conf.Check(bpkg.ImportPath, fset, files, info)
+
+	return check(fset, files, pkg, info), nil
+}
@@ -0,0 +1,82 @@
+package a
+
+import (
+	"errors"
+	"io"
+
+	"github.com/zeebo/errs"
+)
+
+var Error = errs.Class("a")
+
+func unwrapped(r io.Reader) error {
+	_, err := r.Read(nil)
+	if err != nil {
+		return err // want "error returned without being wrapped by a class"
+	}
+	return nil
+}
+
+func wrapped(r io.Reader) (int, error) {
+	n, err := r.Read(nil)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+	return n, nil
+}
+
+func classified() error {
+	return Error.New("classified")
+}
+
+func local() (int, error) {
+	if err := classified(); err != nil {
+		return 0, err
+	}
+	err := errs.New("t")
+	if err != nil {
+		return 0, err
+	}
+	var other = Error.Wrap(io.EOF)
+	if other != nil {
+		return 0, other
+	}
+	return 0, nil
+}
+
+func reassigned(r io.Reader) error {
+	err := classified()
+	_, err = r.Read(nil)
+	if err != nil {
+		return err // want "error returned without being wrapped by a class"
+	}
+	return nil
+}
+
+func literal(r io.Reader) func() error {
+	return func() error {
+		if _, err := r.Read(nil); err != nil {
+			return err // want "error returned without being wrapped by a class"
+		}
+		return nil
+	}
+}
+
+func formatted(err error) error {
+	_ = errs.New("failed: %v", err)        // want "error formatted with %v in New"
+	_ = Error.New("%d failed: %s", 1, err) // want "error formatted with %s in New"
+	_ = Error.New("%*d failed: %w", 1, 2, err)
+	return errs.New("failed: %v", err.Error())
+}
+
+func instance(err error) bool {
+	_ = Error.Instance().Error() // want "Class.Instance\\(\\) used as a concrete error"
+	return errors.Is(err, Error.Instance()) || errs.Is(err, Error.Instance())
+}
+
+func deprecated(err error) error {
+	if _, ok := err.(errs.Causer); ok { // want "errs.Causer is deprecated"
+		return errs.Unwrap(err) // want "errs.Unwrap is deprecated"
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package errs
+
+import "sync/atomic"
+
+// showCodes is non-zero if codes should be included when formatting with "+".
+var showCodes int32
+
+// SetCode sets the machine readable code, like "E1042", for errors in this
+// class. Codes should be unique: Register rejects classes that share a code
+// with a registered class, and ValidateCodes checks all registered classes.
+func (c *Class) SetCode(code string) {
+	c.updateOptions(func(opts *classOptions) { opts.code = code })
+}
+
+// Code returns the code of the outermost class of the error that has one, or
+// the empty string if there is none.
+func Code(err error) (code string) {
+	IsFunc(err, func(err error) bool {
+		if e, ok := asErrorT(err); ok {
+			code = e.class.options().code
+		}
+		return code != ""
+	})
+	return code
+}
+
+// ShowCodes controls if the code of the class of an error is included next to
+// its name when the error is formatted with "%+v". It is disabled by default.
+func ShowCodes(show bool) {
+	var v int32
+	if show {
+		v = 1
+	}
+	atomic.StoreInt32(&showCodes, v)
+}
+
+// ValidateCodes returns an error describing every pair of registered classes
+// that share a code, since codes set after a class is registered are not
+// checked by Register. It returns nil if all the codes are unique.
+func ValidateCodes() error {
+	var group Group
+	seen := make(map[string]*Class)
+	for _, class := range AllClasses() {
+		code := class.options().code
+		if code == "" {
+			continue
+		}
+		if existing, ok := seen[code]; ok {
+			group.Add(New("class %q has the same code %q as class %q",
+				string(*class), code, string(*existing)))
+			continue
+		}
+		seen[code] = class
+	}
+	return group.Err()
+}
+
+// withCode returns the name with the code of the class appended if codes
+// should be shown.
+func withCode(name string, class *Class) string {
+	if atomic.LoadInt32(&showCodes) == 0 {
+		return name
+	}
+	code := class.options().code
+	if code == "" {
+		return name
+	}
+	if name == "" {
+		return "[" + code + "]"
+	}
+	return name + " [" + code + "]"
+}
@@ -0,0 +1,65 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	bar := Class("bar")
+	baz := Class("baz")
+	foo.SetCode("E1")
+	bar.SetCode("E2")
+
+	t.Run("Code", func(t *testing.T) {
+		assert(t, Code(nil) == "")
+		assert(t, Code(errors.New("t")) == "")
+		assert(t, Code(baz.New("t")) == "")
+		assert(t, Code(foo.New("t")) == "E1")
+		assert(t, Code(bar.Wrap(foo.New("t"))) == "E2")
+		assert(t, Code(baz.Wrap(foo.New("t"))) == "E1")
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		err := bar.Wrap(foo.New("t"))
+		assert(t, !strings.Contains(fmt.Sprintf("%+v", err), "[E1]"))
+
+		ShowCodes(true)
+		defer ShowCodes(false)
+
+		assert(t, err.Error() == "bar: foo: t", err.Error())
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "bar [E2]: foo: t\n"), fmt.Sprintf("%+v", err))
+	})
+
+	t.Run("Unique", func(t *testing.T) {
+		defer snapshotRegistry()()
+
+		first := Class("code_test first")
+		first.SetCode("code_test E1")
+		second := Class("code_test second")
+		second.SetCode("code_test E1")
+
+		assert(t, Register(&first) == nil)
+		assert(t, Register(&second) != nil)
+		assert(t, ValidateCodes() == nil)
+
+		third := Class("code_test third")
+		assert(t, Register(&third) == nil)
+		third.SetCode("code_test E1")
+
+		err := ValidateCodes()
+		assert(t, err != nil)
+		t.Log(err)
+		third.SetCode("")
+	})
+}
@@ -0,0 +1,164 @@
+// Package errmetrics keeps per-class counts of the errors created by errs
+package errmetrics
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zeebo/errs"
+)
+
+// key is the type of keys for the counts map. function is only set if the
+// Counters were constructed to count by function.
+type key struct {
+	class    string
+	function string
+}
+
+// Count is the number of errors created for a class and, if the Counters
+// count by function, the function that created them.
+type Count struct {
+	Class    string
+	Function string
+	Value    int64
+}
+
+// Counters keeps counts of errors by class name and optionally by the function
+// that created them.
+type Counters struct {
+	byFunction bool
+
+	mu     sync.Mutex
+	counts map[key]int64
+}
+
+// New constructs Counters. If byFunction is true, counts are additionally
+// split by the function that created the error.
+func New(byFunction bool) *Counters {
+	return &Counters{
+		byFunction: byFunction,
+		counts:     make(map[key]int64),
+	}
+}
+
+// Install causes the Counters to observe every error created by errs. It
+// returns a function that stops observing.
+func (c *Counters) Install() (uninstall func()) {
+	return errs.Observe(c.Observe)
+}
+
+// Observe counts the error. It is usually called through Install, but can be
+// called directly to count errors that were not created by errs.
+func (c *Counters) Observe(err error) {
+	if err == nil {
+		return
+	}
+
+	var k key
+	if namer, ok := err.(errs.Namer); ok {
+		k.class, _ = namer.Name()
+	}
+	if c.byFunction {
+		k.function = origin(err)
+	}
+
+	c.mu.Lock()
+	c.counts[k]++
+	c.mu.Unlock()
+}
+
+// Snapshot returns the current counts sorted by class and function.
+func (c *Counters) Snapshot() []Count {
+	c.mu.Lock()
+	counts := make([]Count, 0, len(c.counts))
+	for k, v := range c.counts {
+		counts = append(counts, Count{
+			Class:    k.class,
+			Function: k.function,
+			Value:    v,
+		})
+	}
+	c.mu.Unlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Class != counts[j].Class {
+			return counts[i].Class < counts[j].Class
+		}
+		return counts[i].Function < counts[j].Function
+	})
+	return counts
+}
+
+// Publish exposes the counts through expvar under the given name. The value is
+// a map from class name to count, or from class name to function name to
+// count if the Counters count by function. Like expvar.Publish, it panics if
+// the name is already in use.
+func (c *Counters) Publish(name string) {
+	expvar.Publish(name, expvar.Func(c.expvarValue))
+}
+
+// expvarValue returns the value published by Publish.
+func (c *Counters) expvarValue() interface{} {
+	if !c.byFunction {
+		out := make(map[string]int64)
+		for _, count := range c.Snapshot() {
+			out[count.Class] += count.Value
+		}
+		return out
+	}
+
+	out := make(map[string]map[string]int64)
+	for _, count := range c.Snapshot() {
+		if out[count.Class] == nil {
+			out[count.Class] = make(map[string]int64)
+		}
+		out[count.Class][count.Function] += count.Value
+	}
+	return out
+}
+
+// ServeHTTP writes the counts in the Prometheus text exposition format.
+func (c *Counters) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	fmt.Fprintln(bw, "# HELP errs_errors_total Number of errors created by class.")
+	fmt.Fprintln(bw, "# TYPE errs_errors_total counter")
+	for _, count := range c.Snapshot() {
+		if c.byFunction {
+			fmt.Fprintf(bw, "errs_errors_total{class=\"%s\",function=\"%s\"} %d\n",
+				escapeLabel(count.Class), escapeLabel(count.Function), count.Value)
+		} else {
+			fmt.Fprintf(bw, "errs_errors_total{class=\"%s\"} %d\n",
+				escapeLabel(count.Class), count.Value)
+		}
+	}
+}
+
+// labelEscaper escapes label values as required by the text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes the label value.
+func escapeLabel(v string) string { return labelEscaper.Replace(v) }
+
+// origin returns the name of the function that created the error, if known.
+func origin(err error) string {
+	stacker, ok := err.(interface{ Stack() []uintptr })
+	if !ok {
+		return ""
+	}
+	pcs := stacker.Stack()
+	if len(pcs) == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	return frame.Function
+}
@@ -0,0 +1,80 @@
+package errmetrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/errs"
+)
+
+func TestCounters(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	var (
+		foo = errs.Class("foo")
+		bar = errs.Class("bar \"quoted\"")
+	)
+
+	t.Run("By Class", func(t *testing.T) {
+		c := New(false)
+		uninstall := c.Install()
+		_ = foo.New("t")
+		_ = foo.New("t")
+		_ = bar.Wrap(foo.New("t"))
+		_ = errs.New("t")
+		uninstall()
+		_ = foo.New("t")
+
+		counts := c.Snapshot()
+		assert(t, len(counts) == 3, counts)
+		assert(t, counts[0] == Count{Class: "", Value: 1}, counts[0])
+		assert(t, counts[1] == Count{Class: "bar \"quoted\"", Value: 1}, counts[1])
+		assert(t, counts[2] == Count{Class: "foo", Value: 3}, counts[2])
+
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		body := rec.Body.String()
+		t.Logf("%s", body)
+
+		assert(t, strings.Contains(body, "# TYPE errs_errors_total counter\n"), body)
+		assert(t, strings.Contains(body, "errs_errors_total{class=\"foo\"} 3\n"), body)
+		assert(t, strings.Contains(body, `errs_errors_total{class="bar \"quoted\""} 1`), body)
+	})
+
+	t.Run("By Function", func(t *testing.T) {
+		c := New(true)
+		uninstall := c.Install()
+		_ = foo.New("t")
+		uninstall()
+
+		counts := c.Snapshot()
+		assert(t, len(counts) == 1, counts)
+		assert(t, counts[0].Class == "foo", counts[0])
+		assert(t, strings.Contains(counts[0].Function, "TestCounters"), counts[0])
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		c := New(false)
+		c.Observe(foo.New("t"))
+		// expvar names can only be published once, so every run needs its own.
+		publishRuns++
+		name := fmt.Sprintf("errmetrics_test_%d", publishRuns)
+		c.Publish(name)
+
+		var out map[string]int64
+		assert(t, json.Unmarshal([]byte(expvar.Get(name).String()), &out) == nil)
+		assert(t, out["foo"] == 1, out)
+	})
+}
+
+// publishRuns counts the runs of the Publish test.
+var publishRuns int
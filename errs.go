@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"runtime"
+	"strings"
+	"sync"
 )
 
 // Namer is implemented by all errors returned in this package. It returns a
@@ -19,16 +21,31 @@ type Namer interface{ Name() (string, bool) }
 // instead.
 type Causer interface{ Cause() error }
 
-// New returns an error not contained in any class. This is the same as calling
-// fmt.Errorf(...) except it captures a stack trace on creation.
+// New returns an error not contained in any class. It captures a stack trace
+// on creation. The message is formatted like fmt.Errorf the first time it is
+// needed, which may be on another goroutine, so the arguments, like byte
+// slices, must not be modified after the call.
 func New(format string, args ...interface{}) error {
-	return (*Class).create(nil, 3, fmt.Errorf(format, args...))
+	return (*Class).create(nil, 3, newMessage(format, args), nil)
 }
 
 // Wrap returns an error not contained in any class. It just associates a stack
 // trace with the error. Wrap returns nil if err is nil.
 func Wrap(err error) error {
-	return (*Class).create(nil, 3, err)
+	return (*Class).create(nil, 3, err, nil)
+}
+
+// NewDepth is like New except that the stack trace skips that many additional
+// callers. A skip of 0 is the same as New. It is useful for helper functions
+// that create errors for their callers.
+func NewDepth(skip int, format string, args ...interface{}) error {
+	return (*Class).create(nil, 3+skip, newMessage(format, args), nil)
+}
+
+// WrapDepth is like Wrap except that the stack trace skips that many
+// additional callers. A skip of 0 is the same as Wrap.
+func WrapDepth(skip int, err error) error {
+	return (*Class).create(nil, 3+skip, err, nil)
 }
 
 // WrapP stores into the error pointer if it contains a non-nil error an error not
@@ -36,7 +53,7 @@ func Wrap(err error) error {
 // does nothing if the pointer or pointed at error is nil.
 func WrapP(err *error) {
 	if err != nil && *err != nil {
-		*err = (*Class).create(nil, 3, *err)
+		*err = (*Class).create(nil, 3, *err, nil)
 	}
 }
 
@@ -86,7 +103,7 @@ func Unwrap(err error) error {
 // Classes returns all the classes that have wrapped the error.
 func Classes(err error) (classes []*Class) {
 	IsFunc(err, func(err error) bool {
-		if e, ok := err.(*errorT); ok {
+		if e, ok := asErrorT(err); ok {
 			classes = append(classes, e.class)
 		}
 		return false
@@ -140,28 +157,45 @@ type Class string
 // this class.
 func (c *Class) Has(err error) bool {
 	return IsFunc(err, func(err error) bool {
-		errt, ok := err.(*errorT)
+		errt, ok := asErrorT(err)
 		return ok && errt.class == c
 	})
 }
 
 // New constructs an error with the format string that will be contained by
-// this class. This is the same as calling Wrap(fmt.Errorf(...)).
+// this class. The message is formatted like fmt.Errorf the first time it is
+// needed, which may be on another goroutine, so the arguments, like byte
+// slices, must not be modified after the call.
 func (c *Class) New(format string, args ...interface{}) error {
-	return c.create(3, fmt.Errorf(format, args...))
+	return c.create(3, newMessage(format, args), nil)
 }
 
 // Wrap returns a new error based on the passed in error that is contained in
-// this class. Wrap returns nil if err is nil.
+// this class. The new error keeps any Timeout, Temporary and Code methods of
+// the passed in error, like those of net.Error, so that type assertions for
+// them still work. Wrap returns nil if err is nil.
 func (c *Class) Wrap(err error) error {
-	return c.create(3, err)
+	return c.create(3, err, nil)
+}
+
+// NewDepth is like New except that the stack trace skips that many additional
+// callers. A skip of 0 is the same as New.
+func (c *Class) NewDepth(skip int, format string, args ...interface{}) error {
+	return c.create(3+skip, newMessage(format, args), nil)
+}
+
+// WrapDepth is like Wrap except that the stack trace skips that many
+// additional callers. A skip of 0 is the same as Wrap. It is useful for
+// helper functions that wrap errors for their callers.
+func (c *Class) WrapDepth(skip int, err error) error {
+	return c.create(3+skip, err, nil)
 }
 
 // WrapP stores into the error pointer if it contains a non-nil error an error contained
 // in this class. WrapP does nothing if the pointer or pointed at error is nil.
 func (c *Class) WrapP(err *error) {
 	if err != nil && *err != nil {
-		*err = c.create(3, *err)
+		*err = c.create(3, *err, nil)
 	}
 }
 
@@ -182,33 +216,45 @@ func (c *Class) Instance() error {
 }
 
 // create constructs the error, or just adds the class to the error, keeping
-// track of the stack if it needs to construct it.
-func (c *Class) create(depth int, err error) error {
+// track of the stack if it needs to construct it. The stack starts depth
+// frames up, as passed to runtime.Callers, skipping any helper functions. Any
+// fields are added to the error.
+func (c *Class) create(depth int, err error, fields []interface{}) error {
 	if err == nil {
 		return nil
 	}
 
 	var pcs []uintptr
-	if err, ok := err.(*errorT); ok {
-		if c == nil || err.class == c {
+	if errt, ok := asErrorT(err); ok {
+		if c == nil || errt.class == c {
+			if len(fields) == 0 {
+				return err
+			}
+			copied := *errt
+			copied.fields = append(fields[:len(fields):len(fields)], errt.fields...)
+			err := preserve(&copied)
+			notify(err)
 			return err
 		}
-		pcs = err.pcs
+		pcs = errt.pcs
 	}
 
 	errt := &errorT{
-		class: c,
-		err:   err,
-		pcs:   pcs,
+		class:  c,
+		err:    err,
+		pcs:    pcs,
+		fields: fields,
 	}
 
 	if errt.pcs == nil {
 		errt.pcs = make([]uintptr, 64)
 		n := runtime.Callers(depth, errt.pcs)
-		errt.pcs = errt.pcs[:n:n]
+		errt.pcs = skipHelpers(errt.pcs[:n:n])
 	}
 
-	return errt
+	err = preserve(errt)
+	notify(err)
+	return err
 }
 
 type classMembershipChecker Class
@@ -223,9 +269,10 @@ func (cmc *classMembershipChecker) Error() string {
 
 // errorT is the type of errors returned from this package.
 type errorT struct {
-	class *Class
-	err   error
-	pcs   []uintptr
+	class  *Class
+	err    error
+	pcs    []uintptr
+	fields []interface{}
 }
 
 var ( // ensure *errorT implements the helper interfaces.
@@ -234,27 +281,119 @@ var ( // ensure *errorT implements the helper interfaces.
 	_ error  = (*errorT)(nil)
 )
 
+// base returns the error. It allows the types that preserve the methods of
+// the wrapped error to be converted back into an *errorT.
+func (e *errorT) base() *errorT { return e }
+
+// asErrorT returns the *errorT for errors created by this package.
+func asErrorT(err error) (*errorT, bool) {
+	if b, ok := err.(interface{ base() *errorT }); ok {
+		return b.base(), true
+	}
+	return nil, false
+}
+
 // Stack returns the pcs for the stack trace associated with the error.
 func (e *errorT) Stack() []uintptr { return e.pcs }
 
 // errorT implements the error interface.
 func (e *errorT) Error() string {
-	return fmt.Sprintf("%v", e)
+	return e.text(false)
 }
 
 // Format handles the formatting of the error. Using a "+" on the format string
-// specifier will also write the stack trace.
+// specifier will also write the fields of the error and every error it wraps
+// and the stack trace as configured by the Layout, and "%#v" writes a Go
+// syntax like representation useful for debugging. Other verbs, like "%s",
+// "%q" and "%x", format the message like a string without a stack trace.
+// Widths and precisions pad and truncate the message.
 func (e *errorT) Format(f fmt.State, c rune) {
-	sep := ""
-	if e.class != nil && *e.class != "" {
-		fmt.Fprintf(f, "%s", string(*e.class))
-		sep = ": "
+	switch {
+	case c == 'v' && f.Flag(int('#')):
+		class := "nil"
+		if e.class != nil {
+			class = fmt.Sprintf("%q", string(*e.class))
+		}
+		fmt.Fprintf(f, "&errs.errorT{class: %s, err: %T(%q), pcs: %d",
+			class, e.err, e.err.Error(), len(e.pcs))
+		if len(e.fields) > 0 {
+			fmt.Fprintf(f, ", fields: %s", formatFields(e.fields))
+		}
+		io.WriteString(f, "}")
+
+	case c == 'v' && f.Flag(int('+')):
+		layout := layoutFor(e.class)
+		fmt.Fprintf(f, directive(f, 's', "-"), e.text(true))
+		if fields := formatChainFields(e); fields != "" {
+			fmt.Fprintf(f, " %s", fields)
+		}
+		summarizeStack(f, e.pcs, layout)
+	case c == 'v':
+		fmt.Fprintf(f, directive(f, 's', "-"), e.Error())
+
+	case c == 's':
+		fmt.Fprintf(f, directive(f, 's', "-"), e.Error())
+	case c == 'q':
+		fmt.Fprintf(f, directive(f, 'q', "-+#"), e.Error())
+
+	default:
+		fmt.Fprintf(f, directive(f, c, "+-# 0"), e.Error())
+	}
+}
+
+// text returns the class name of the error and the text of the underlying
+// error in the style of the class. If verbose is true, the error is being
+// formatted with "%+v".
+func (e *errorT) text(verbose bool) string {
+	return e.render(verbose, verbose)
+}
+
+// render is like text, except that the code of the class is only added if
+// codes is true. Wrapped errors from this package are rendered verbosely as
+// well so that their hidden names are shown, but without their codes.
+func (e *errorT) render(verbose, codes bool) string {
+	name, _ := e.Name()
+	if codes {
+		name = withCode(name, e.class)
+	}
+
+	var text string
+	if inner, ok := asErrorT(e.err); ok && verbose {
+		text = inner.render(true, false)
+	} else {
+		text = e.err.Error()
 	}
-	if text := e.err.Error(); len(text) > 0 {
-		fmt.Fprintf(f, "%s%v", sep, text)
+
+	var sb strings.Builder
+	writeText(&sb, e.class, name, text, verbose)
+	return sb.String()
+}
+
+// writeText writes the name of the class of an error and the text of the
+// underlying error in the style configured for the class. If verbose is true,
+// the error is being formatted with "%+v".
+func writeText(w io.Writer, class *Class, name, text string, verbose bool) {
+	opts := class.options()
+	if opts.nameStyle == NameHidden && !verbose {
+		name = ""
 	}
-	if f.Flag(int('+')) {
-		summarizeStack(f, e.pcs)
+
+	switch {
+	case name == "":
+		io.WriteString(w, text)
+	case text == "":
+		io.WriteString(w, name)
+	case opts.nameStyle == NameSuffix:
+		fmt.Fprintf(w, "%s (%s)", text, name)
+	default:
+		sep := opts.separator
+		if layout := layoutFor(class); verbose && layout.Separator != "" {
+			sep = layout.Separator
+		}
+		if sep == "" {
+			sep = ": "
+		}
+		fmt.Fprintf(w, "%s%s%s", name, sep, text)
 	}
 }
 
@@ -285,14 +424,83 @@ func (e *errorT) Is(err error) bool {
 	return ok && e.class == (*Class)(cmc)
 }
 
-// summarizeStack writes stack line entries to the writer.
-func summarizeStack(w io.Writer, pcs []uintptr) {
+//
+// messages
+//
+
+// message is the error constructed by New. It remembers the format string and
+// arguments the error was created with and formats them the first time the
+// message is needed, so that errors which are created and discarded without
+// being printed are cheap. The payload holds the value passed to the New
+// method of a TypedClass, if any.
+type message struct {
+	format  string
+	args    []interface{}
+	payload interface{}
+	once    sync.Once
+	err     error
+}
+
+// newMessage constructs a message from the format string and arguments.
+func newMessage(format string, args []interface{}) *message {
+	return &message{format: format, args: args}
+}
+
+// rendered returns the result of formatting the message, formatting it if it
+// has not been already.
+func (m *message) rendered() error {
+	m.once.Do(func() { m.err = fmt.Errorf(m.format, m.args...) })
+	return m.err
+}
+
+// Error returns the formatted message.
+func (m *message) Error() string { return m.rendered().Error() }
+
+// Unwrap returns the result of formatting the message so that any errors
+// wrapped with %w are reachable. Only arguments that are errors can be wrapped
+// with %w, so if there are none it returns nil without formatting the message.
+func (m *message) Unwrap() error {
+	for _, arg := range m.args {
+		if _, ok := arg.(error); ok {
+			return m.rendered()
+		}
+	}
+	return nil
+}
+
+// directive reconstructs a format directive for the verb from the width,
+// precision and any of the flags of the state that are listed in flags.
+func directive(f fmt.State, verb rune, flags string) string {
+	var sb strings.Builder
+	sb.WriteByte('%')
+	for _, flag := range flags {
+		if f.Flag(int(flag)) {
+			sb.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		fmt.Fprintf(&sb, "%d", width)
+	}
+	if prec, ok := f.Precision(); ok {
+		fmt.Fprintf(&sb, ".%d", prec)
+	}
+	sb.WriteRune(verb)
+	return sb.String()
+}
+
+// summarizeStack writes stack line entries to the writer using the layout.
+// The frames are expanded from the pcs by the runtime so that inlined calls
+// are included.
+func summarizeStack(w io.Writer, pcs []uintptr, layout *Layout) {
+	if len(pcs) == 0 {
+		return
+	}
 	frames := runtime.CallersFrames(pcs)
 	for {
 		frame, more := frames.Next()
+		layout.writeFrame(w, frame)
 		if !more {
 			return
 		}
-		fmt.Fprintf(w, "\n\t%s:%d", frame.Function, frame.Line)
 	}
 }
@@ -109,14 +109,30 @@ func TestErrs(t *testing.T) {
 
 			assert(t,
 				!strings.Contains(fmt.Sprintf("%v", err), "\n"),
-				"%v format contains newline",
+				"plain format contains newline",
 			)
 			assert(t,
 				strings.Contains(fmt.Sprintf("%+v", err), "\n"),
-				"%+v format does not contain newline",
+				"plus format does not contain newline",
 			)
 		})
 
+		t.Run("Format Verbs", func(t *testing.T) {
+			err := foo.New("hello")
+
+			assert(t, fmt.Sprintf("%s", err) == "foo: hello")
+			assert(t, fmt.Sprintf("%+s", err) == "foo: hello")
+			assert(t, fmt.Sprintf("%q", err) == `"foo: hello"`)
+			assert(t, fmt.Sprintf("%8.3v|", err) == "     foo|")
+			assert(t, fmt.Sprintf("%-6.3s|", err) == "foo   |")
+			assert(t, fmt.Sprintf("%x", err) == fmt.Sprintf("%x", "foo: hello"))
+			assert(t, fmt.Sprintf("% X", err) == fmt.Sprintf("% X", "foo: hello"))
+
+			debug := fmt.Sprintf("%#v", err)
+			assert(t, strings.HasPrefix(debug, `&errs.errorT{class: "foo", err: *errs.message("hello"), pcs: `), debug)
+			assert(t, strings.HasPrefix(fmt.Sprintf("%#v", Wrap(errors.New("t"))), `&errs.errorT{class: nil, err: *errors.errorString("t")`))
+		})
+
 		t.Run("Unwrap", func(t *testing.T) {
 			err := fmt.Errorf("t")
 
@@ -276,9 +292,46 @@ func TestErrs(t *testing.T) {
 			go func() { bar.Wrap(err); wg.Done() }()
 			wg.Wait()
 		})
+
+		t.Run("Lazy", func(t *testing.T) {
+			var calls int
+			err := foo.New("%v", stringerFunc(func() string { calls++; return "t" }))
+			assert(t, calls == 0, calls)
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() { _ = err.Error(); wg.Done() }()
+			go func() { _ = fmt.Sprintf("%+v", err); wg.Done() }()
+			wg.Wait()
+
+			assert(t, err.Error() == "foo: t", err.Error())
+			assert(t, calls == 1, calls)
+		})
+
+		t.Run("Lazy Chain", func(t *testing.T) {
+			var calls int
+			err := foo.New("%v", stringerFunc(func() string { calls++; return "t" }))
+
+			assert(t, foo.Has(err) && !bar.Has(err))
+			assert(t, len(Classes(err)) == 1)
+			assert(t, !errors.Is(err, errors.New("t")))
+			assert(t, Code(err) == "" && Canonical(err) == nil)
+			assert(t, calls == 0, calls)
+		})
+
+		t.Run("Lazy Wrapped", func(t *testing.T) {
+			inner := errors.New("inner")
+			err := foo.New("t: %w", inner)
+			assert(t, errors.Is(err, inner))
+			assert(t, err.Error() == "foo: t: inner", err.Error())
+		})
 	})
 }
 
+type stringerFunc func() string
+
+func (s stringerFunc) String() string { return s() }
+
 func BenchmarkErrs(b *testing.B) {
 	foo := Class("foo")
 	err := errors.New("bench")
@@ -296,4 +349,18 @@ func BenchmarkErrs(b *testing.B) {
 			_ = foo.New("bench")
 		}
 	})
+
+	b.Run("New Args", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = foo.New("bench %d: %s", i, "arg")
+		}
+	})
+
+	b.Run("New Args Error", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = foo.New("bench %d: %s", i, "arg").Error()
+		}
+	})
 }
@@ -0,0 +1,108 @@
+// Package errstest provides helpers for testing errors created with errs
+package errstest
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/errs"
+)
+
+// RequireClass fails the test immediately if the error does not have the
+// class.
+func RequireClass(t testing.TB, err error, class *errs.Class) {
+	t.Helper()
+	if !class.Has(err) {
+		t.Fatalf("expected error to have class %q, but it did not: %+v", string(*class), err)
+	}
+}
+
+// RequireNotClass fails the test immediately if the error has the class.
+func RequireNotClass(t testing.TB, err error, class *errs.Class) {
+	t.Helper()
+	if class.Has(err) {
+		t.Fatalf("expected error to not have class %q, but it did: %+v", string(*class), err)
+	}
+}
+
+// RequireFrames fails the test immediately if any of the function names do
+// not appear in the stack traces recorded by the error or any error it wraps.
+// Names match the full function name, like "github.com/zeebo/errs.New", or any
+// suffix of it starting after a "/" or ".", like "errs.New".
+func RequireFrames(t testing.TB, err error, funcNames ...string) {
+	t.Helper()
+	functions := Frames(err)
+	for _, name := range funcNames {
+		if !containsFunction(functions, name) {
+			t.Fatalf("expected a frame for %q in the stack of the error, but it only had %q",
+				name, functions)
+		}
+	}
+}
+
+// Frames returns the function names of the stack traces recorded by the error
+// and any error it wraps, outermost error first.
+func Frames(err error) (functions []string) {
+	errs.IsFunc(err, func(err error) bool {
+		if stacker, ok := err.(interface{ Stack() []uintptr }); ok {
+			frames := runtime.CallersFrames(stacker.Stack())
+			for {
+				frame, more := frames.Next()
+				if frame.Function != "" {
+					functions = append(functions, frame.Function)
+				}
+				if !more {
+					break
+				}
+			}
+		}
+		return false
+	})
+	return functions
+}
+
+// containsFunction returns true if any of the functions match the name.
+func containsFunction(functions []string, name string) bool {
+	for _, function := range functions {
+		if function == name {
+			return true
+		}
+		if strings.HasSuffix(function, name) {
+			if c := function[len(function)-len(name)-1]; c == '/' || c == '.' {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var (
+	// lineRegexp matches line numbers and any pc offset following them.
+	lineRegexp = regexp.MustCompile(`:\d+( \+0x[0-9a-f]+)?$`)
+
+	// pathRegexp matches directories in paths and package paths.
+	pathRegexp = regexp.MustCompile(`\S*/`)
+
+	// addrRegexp matches addresses.
+	addrRegexp = regexp.MustCompile(`0x[0-9a-f]+`)
+)
+
+// Golden returns the "%+v" formatting of the error normalized so that it can
+// be compared against golden output without breaking whenever a file is
+// edited or moved: line numbers and pc offsets are removed, paths and package
+// paths are reduced to their last element, and addresses are replaced with
+// "0x?".
+func Golden(err error) string {
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "\t") {
+			line = lineRegexp.ReplaceAllString(line, "")
+			line = pathRegexp.ReplaceAllString(line, "")
+		}
+		lines[i] = addrRegexp.ReplaceAllString(line, "0x?")
+	}
+	return strings.Join(lines, "\n")
+}
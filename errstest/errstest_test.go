@@ -0,0 +1,84 @@
+package errstest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zeebo/errs"
+)
+
+// fakeTB records if the test would have failed.
+type fakeTB struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	f.message = fmt.Sprintf(format, args...)
+}
+
+func createError(class *errs.Class) error { return class.New("created %d", 42) }
+
+func TestRequire(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := errs.Class("foo")
+	bar := errs.Class("bar")
+	err := bar.Wrap(createError(&foo))
+
+	t.Run("Class", func(t *testing.T) {
+		RequireClass(t, err, &foo)
+		RequireNotClass(t, err, &errs.Internal)
+
+		tb := new(fakeTB)
+		RequireClass(tb, err, &errs.Internal)
+		assert(t, tb.failed)
+		t.Log(tb.message)
+
+		tb = new(fakeTB)
+		RequireNotClass(tb, err, &bar)
+		assert(t, tb.failed)
+	})
+
+	t.Run("Frames", func(t *testing.T) {
+		RequireFrames(t, err, "createError", "errstest.createError",
+			"github.com/zeebo/errs/errstest.createError", "TestRequire")
+
+		tb := new(fakeTB)
+		RequireFrames(tb, err, "Error")
+		assert(t, tb.failed)
+		t.Log(tb.message)
+
+		tb = new(fakeTB)
+		RequireFrames(tb, err, "notCalled")
+		assert(t, tb.failed)
+	})
+}
+
+func TestGolden(t *testing.T) {
+	foo := errs.Class("foo")
+	err := errs.Combine(createError(&foo), errs.New("second"))
+
+	golden := Golden(err)
+	t.Logf("%s", golden)
+
+	expected := "group:\n--- foo: created 42\n\terrstest.createError\n"
+	if !strings.HasPrefix(golden, expected) {
+		t.Fatalf("expected prefix %q, got %q", expected, golden)
+	}
+	for _, line := range strings.Split(golden, "\n") {
+		if strings.HasPrefix(line, "\t") && strings.ContainsAny(line, "0123456789/:") {
+			t.Fatalf("line %q not normalized", line)
+		}
+	}
+}
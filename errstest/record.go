@@ -0,0 +1,86 @@
+//go:build go1.14
+// +build go1.14
+
+package errstest
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zeebo/errs"
+)
+
+// Recorder collects the errors created by errs during a test.
+type Recorder struct {
+	function string
+
+	mu     sync.Mutex
+	errors []error
+}
+
+// Record starts collecting every error created by errs while the test runs,
+// even ones that are later swallowed, and logs them with their classes and
+// stacks if the test fails. It should be called directly from the test
+// function. Only errors whose stacks pass through that function or any
+// function literal inside of it are collected, which includes goroutines it
+// starts, so that errors from other tests running in parallel are ignored.
+func Record(t testing.TB) *Recorder {
+	t.Helper()
+
+	r := new(Recorder)
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			r.function = fn.Name()
+		}
+	}
+
+	unregister := errs.Observe(r.observe)
+	t.Cleanup(func() {
+		unregister()
+		if !t.Failed() {
+			return
+		}
+		errors := r.Errors()
+		t.Logf("errs errors created during the test: %d", len(errors))
+		for i, err := range errors {
+			t.Logf("error %d: %+v", i, err)
+		}
+	})
+
+	return r
+}
+
+// Errors returns the errors collected so far in the order they were created.
+func (r *Recorder) Errors() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]error(nil), r.errors...)
+}
+
+// observe collects the error if it was created by the test.
+func (r *Recorder) observe(err error) {
+	if !r.created(err) {
+		return
+	}
+
+	r.mu.Lock()
+	r.errors = append(r.errors, err)
+	r.mu.Unlock()
+}
+
+// created returns true if the stack of the error passes through the function
+// that called Record or one of its function literals.
+func (r *Recorder) created(err error) bool {
+	if r.function == "" {
+		return true
+	}
+	for _, function := range Frames(err) {
+		if function == r.function || strings.HasPrefix(function, r.function+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+//go:build go1.14
+// +build go1.14
+
+package errstest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zeebo/errs"
+)
+
+func TestRecord(t *testing.T) {
+	foo := errs.Class("foo")
+
+	var r *Recorder
+	t.Run("Record", func(t *testing.T) {
+		r = Record(t)
+
+		_ = createError(&foo)
+		_ = foo.Wrap(errs.New("swallowed"))
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = errs.New("in goroutine")
+		}()
+		wg.Wait()
+	})
+
+	// created after the recording test finished, so it is not recorded.
+	_ = errs.New("not recorded")
+
+	errors := r.Errors()
+	if len(errors) != 4 {
+		t.Fatalf("expected 4 errors, got %d: %v", len(errors), errors)
+	}
+	if errors[0].Error() != "foo: created 42" {
+		t.Fatal("unexpected first error:", errors[0])
+	}
+	if errors[1].Error() != "swallowed" || errors[2].Error() != "foo: swallowed" {
+		t.Fatal("unexpected swallowed errors:", errors[1], errors[2])
+	}
+	if !strings.Contains(errors[3].Error(), "goroutine") {
+		t.Fatal("unexpected goroutine error:", errors[3])
+	}
+}
+
+func TestRecordScope(t *testing.T) {
+	r := Record(t)
+
+	done := make(chan struct{})
+	go unrelated(done)
+	<-done
+
+	if errors := r.Errors(); len(errors) != 0 {
+		t.Fatal("expected no errors, got", errors)
+	}
+}
+
+// unrelated creates an error on a goroutine that does not pass through the
+// test function.
+func unrelated(done chan struct{}) {
+	_ = errs.New("unrelated")
+	close(done)
+}
@@ -0,0 +1,111 @@
+package errs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// fieldsKey is the context key for the fields added with WithContextFields.
+type fieldsKey struct{}
+
+// WithContextFields returns a context carrying the fields in addition to any
+// fields already carried by ctx. The fields are alternating keys and values,
+// like "request_id", id. Errors created with NewCtx or WrapCtx copy the fields
+// carried by their context.
+func WithContextFields(ctx context.Context, kv ...interface{}) context.Context {
+	existing := contextFields(ctx)
+	fields := make([]interface{}, 0, len(existing)+len(kv))
+	fields = append(fields, existing...)
+	fields = append(fields, kv...)
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// contextFields returns the fields carried by the context.
+func contextFields(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+	return fields
+}
+
+// NewCtx is like New except that the error also has the fields carried by the
+// context.
+func NewCtx(ctx context.Context, format string, args ...interface{}) error {
+	return (*Class).create(nil, 3, newMessage(format, args), contextFields(ctx))
+}
+
+// WrapCtx is like Wrap except that the error also has the fields carried by
+// the context.
+func WrapCtx(ctx context.Context, err error) error {
+	return (*Class).create(nil, 3, err, contextFields(ctx))
+}
+
+// NewCtx is like New except that the error also has the fields carried by the
+// context.
+func (c *Class) NewCtx(ctx context.Context, format string, args ...interface{}) error {
+	return c.create(3, newMessage(format, args), contextFields(ctx))
+}
+
+// WrapCtx is like Wrap except that the error also has the fields carried by
+// the context.
+func (c *Class) WrapCtx(ctx context.Context, err error) error {
+	return c.create(3, err, contextFields(ctx))
+}
+
+// Fields returns the fields of the error and every error it wraps as
+// alternating keys and values, outermost first. The returned slice may be
+// modified.
+func Fields(err error) (fields []interface{}) {
+	IsFunc(err, func(err error) bool {
+		if e, ok := asErrorT(err); ok {
+			fields = append(fields, e.fields...)
+		}
+		return false
+	})
+	return fields
+}
+
+// formatFields formats the fields as key=value pairs inside braces. A key
+// without a value is written alone.
+func formatFields(fields []interface{}) string {
+	return "{" + strings.Join(fieldPairs(fields), ", ") + "}"
+}
+
+// formatChainFields formats the fields of the error and every error it wraps
+// like formatFields, outermost first, leaving out pairs that were already
+// written. It returns the empty string if there are no fields.
+func formatChainFields(err error) string {
+	var pairs []string
+	seen := make(map[string]bool)
+	IsFunc(err, func(err error) bool {
+		if e, ok := asErrorT(err); ok {
+			for _, pair := range fieldPairs(e.fields) {
+				if !seen[pair] {
+					seen[pair] = true
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+		return false
+	})
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// fieldPairs formats each key and value of the fields as key=value. A key
+// without a value is formatted alone.
+func fieldPairs(fields []interface{}) []string {
+	pairs := make([]string, 0, (len(fields)+1)/2)
+	for i := 0; i < len(fields); i += 2 {
+		if i+1 < len(fields) {
+			pairs = append(pairs, fmt.Sprintf("%v=%v", fields[i], fields[i+1]))
+		} else {
+			pairs = append(pairs, fmt.Sprintf("%v", fields[i]))
+		}
+	}
+	return pairs
+}
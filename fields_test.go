@@ -0,0 +1,71 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	bar := Class("bar")
+
+	ctx := WithContextFields(context.Background(), "request_id", "abc")
+	ctx = WithContextFields(ctx, "tenant", 7)
+
+	t.Run("Context", func(t *testing.T) {
+		assert(t, Fields(NewCtx(context.Background(), "t")) == nil)
+		assert(t, Fields(New("t")) == nil)
+
+		expected := []interface{}{"request_id", "abc", "tenant", 7}
+		assert(t, reflect.DeepEqual(Fields(NewCtx(ctx, "t")), expected))
+		assert(t, reflect.DeepEqual(Fields(foo.NewCtx(ctx, "t")), expected))
+		assert(t, reflect.DeepEqual(Fields(WrapCtx(ctx, errors.New("t"))), expected))
+		assert(t, reflect.DeepEqual(Fields(foo.WrapCtx(ctx, errors.New("t"))), expected))
+		assert(t, foo.WrapCtx(ctx, nil) == nil)
+	})
+
+	t.Run("Chain", func(t *testing.T) {
+		inner := foo.NewCtx(WithContextFields(context.Background(), "inner", 1), "t")
+
+		err := bar.WrapCtx(ctx, inner)
+		assert(t, bar.Has(err) && foo.Has(err))
+		assert(t, reflect.DeepEqual(Fields(err), []interface{}{"request_id", "abc", "tenant", 7, "inner", 1}), Fields(err))
+
+		err = foo.WrapCtx(ctx, inner)
+		assert(t, len(Classes(err)) == 1)
+		assert(t, reflect.DeepEqual(Fields(err), []interface{}{"request_id", "abc", "tenant", 7, "inner", 1}), Fields(err))
+		assert(t, reflect.DeepEqual(Fields(inner), []interface{}{"inner", 1}), Fields(inner))
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		err := foo.NewCtx(WithContextFields(ctx, "odd"), "t")
+		assert(t, err.Error() == "foo: t", err.Error())
+
+		out := fmt.Sprintf("%+v", err)
+		assert(t, strings.HasPrefix(out, "foo: t {request_id=abc, tenant=7, odd}\n"), out)
+
+		out = fmt.Sprintf("%#v", err)
+		assert(t, strings.HasSuffix(out, ", fields: {request_id=abc, tenant=7, odd}}"), out)
+	})
+
+	t.Run("Format Wrapped", func(t *testing.T) {
+		err := bar.Wrap(foo.NewCtx(ctx, "t"))
+		out := fmt.Sprintf("%+v", err)
+		assert(t, strings.HasPrefix(out, "bar: foo: t {request_id=abc, tenant=7}\n"), out)
+
+		err = bar.WrapCtx(WithContextFields(ctx, "outer", 2), foo.NewCtx(ctx, "t"))
+		out = fmt.Sprintf("%+v", err)
+		assert(t, strings.HasPrefix(out, "bar: foo: t {request_id=abc, tenant=7, outer=2}\n"), out)
+	})
+}
@@ -0,0 +1,99 @@
+package errs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"runtime"
+)
+
+// Fingerprint returns a short identifier for the kind of failure the error
+// represents, suitable for grouping identical failures. It is computed from
+// the classes of the error, the function and file that created it, and the
+// format string it was created with rather than the rendered message. Thus,
+// errors created from the same call with different arguments share a
+// fingerprint, even across builds that change line numbers. Errors not created
+// by this package contribute their type and message. Fingerprint returns the
+// empty string if err is nil. Use FingerprintWithoutOrigin to group the same
+// failure across the places that create it.
+func Fingerprint(err error) string {
+	return fingerprintOf(err, true)
+}
+
+// FingerprintWithoutOrigin is like Fingerprint except that the function and
+// file that created the error are left out, so that the same failure raised
+// from different places shares a fingerprint.
+func FingerprintWithoutOrigin(err error) string {
+	return fingerprintOf(err, false)
+}
+
+// fingerprintOf returns the fingerprint of the error, including the origin if
+// origin is true.
+func fingerprintOf(err error, origin bool) string {
+	if err == nil {
+		return ""
+	}
+	h := sha256.New()
+	fingerprint(h, err, origin, 0)
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// fingerprint writes the parts of the error that identify it into the hash,
+// including where it was created if origin is true.
+func fingerprint(h hash.Hash, err error, origin bool, depth int) {
+	if err == nil || depth >= maxUnwrap {
+		return
+	}
+
+	if errt, ok := asErrorT(err); ok {
+		err = errt
+	}
+
+	switch e := err.(type) {
+	case *errorT:
+		if e.class != nil {
+			fmt.Fprintf(h, "class:%q\n", string(*e.class))
+		}
+		if origin && len(e.pcs) > 0 {
+			frame, _ := runtime.CallersFrames(e.pcs).Next()
+			fmt.Fprintf(h, "origin:%q:%q\n", frame.Function, filepath.Base(frame.File))
+		}
+		fingerprint(h, e.err, origin, depth+1)
+		return
+
+	case *message:
+		fmt.Fprintf(h, "format:%q\n", e.format)
+		// only descend into the rendered message if it wrapped errors with %w.
+		// otherwise it is just the rendered text we want to ignore.
+		switch rendered := e.Unwrap(); rendered.(type) {
+		case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+			fingerprint(h, rendered, origin, depth+1)
+		}
+		return
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		fmt.Fprintf(h, "wrapper:%T\n", err)
+		fingerprint(h, u.Unwrap(), origin, depth+1)
+	case Causer:
+		fmt.Fprintf(h, "wrapper:%T\n", err)
+		fingerprint(h, u.Cause(), origin, depth+1)
+
+	case interface{ Ungroup() []error }:
+		fmt.Fprintf(h, "group:%T\n", err)
+		for _, err := range u.Ungroup() {
+			fingerprint(h, err, origin, depth+1)
+		}
+	case interface{ Unwrap() []error }:
+		fmt.Fprintf(h, "group:%T\n", err)
+		for _, err := range u.Unwrap() {
+			fingerprint(h, err, origin, depth+1)
+		}
+
+	default:
+		fmt.Fprintf(h, "error:%T:%q\n", err, err.Error())
+	}
+}
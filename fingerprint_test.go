@@ -0,0 +1,64 @@
+package errs
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	bar := Class("bar")
+
+	notFound := func(id int) error { return foo.New("user %d not found", id) }
+	otherNotFound := func(id int) error { return foo.New("user %d not found", id) }
+
+	t.Run("Nil", func(t *testing.T) {
+		assert(t, Fingerprint(nil) == "")
+	})
+
+	t.Run("Ignores Arguments", func(t *testing.T) {
+		assert(t, Fingerprint(notFound(42)) == Fingerprint(notFound(43)))
+		assert(t, len(Fingerprint(notFound(42))) == 16)
+	})
+
+	t.Run("Includes Origin", func(t *testing.T) {
+		assert(t, Fingerprint(notFound(42)) != Fingerprint(otherNotFound(42)))
+	})
+
+	t.Run("Without Origin", func(t *testing.T) {
+		assert(t, FingerprintWithoutOrigin(nil) == "")
+		assert(t, FingerprintWithoutOrigin(notFound(42)) == FingerprintWithoutOrigin(otherNotFound(43)))
+		assert(t, FingerprintWithoutOrigin(notFound(42)) != Fingerprint(notFound(42)))
+		assert(t, FingerprintWithoutOrigin(notFound(42)) != FingerprintWithoutOrigin(bar.New("user %d not found", 42)))
+	})
+
+	t.Run("Includes Classes", func(t *testing.T) {
+		err := notFound(42)
+		assert(t, Fingerprint(err) != Fingerprint(bar.Wrap(err)))
+		assert(t, Fingerprint(bar.Wrap(notFound(42))) == Fingerprint(bar.Wrap(notFound(43))))
+	})
+
+	t.Run("Includes Wrapped", func(t *testing.T) {
+		wrap := func(err error) error { return New("failed: %w", err) }
+		assert(t, Fingerprint(wrap(io.EOF)) == Fingerprint(wrap(io.EOF)))
+		assert(t, Fingerprint(wrap(io.EOF)) != Fingerprint(wrap(io.ErrUnexpectedEOF)))
+	})
+
+	t.Run("Foreign", func(t *testing.T) {
+		assert(t, Fingerprint(errors.New("a")) == Fingerprint(errors.New("a")))
+		assert(t, Fingerprint(errors.New("a")) != Fingerprint(errors.New("b")))
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		assert(t, Fingerprint(Combine(notFound(1), io.EOF)) == Fingerprint(Combine(notFound(2), io.EOF)))
+		assert(t, Fingerprint(Combine(notFound(1), io.EOF)) != Fingerprint(Combine(io.EOF, notFound(1))))
+	})
+}
@@ -0,0 +1,117 @@
+package errs
+
+import (
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// SkipPackages returns a function for Layout.Skip that skips frames in
+// packages whose import path starts with any of the prefixes. A prefix only
+// matches whole path elements, so "example.com/foo" matches
+// "example.com/foo/bar" but not "example.com/foobar".
+func SkipPackages(prefixes ...string) func(frame runtime.Frame) bool {
+	return func(frame runtime.Frame) bool {
+		pkg := framePackage(frame)
+		for _, prefix := range prefixes {
+			prefix = strings.TrimSuffix(prefix, "/")
+			if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SkipStdlib is a function for Layout.Skip that skips frames in the standard
+// library, including the runtime.
+func SkipStdlib(frame runtime.Frame) bool {
+	pkg := framePackage(frame)
+	if pkg == "" || pkg == "main" {
+		return false
+	}
+	first := pkg
+	if i := strings.IndexByte(pkg, '/'); i >= 0 {
+		first = pkg[:i]
+	}
+	return !strings.Contains(first, ".")
+}
+
+// SkipRuntime is a function for Layout.Skip that skips frames in the runtime,
+// like runtime.goexit at the bottom of every goroutine.
+func SkipRuntime(frame runtime.Frame) bool {
+	pkg := framePackage(frame)
+	return pkg == "runtime" || strings.HasPrefix(pkg, "runtime/internal/")
+}
+
+// SkipAny returns a function for Layout.Skip that skips frames skipped by any
+// of the functions.
+func SkipAny(skips ...func(frame runtime.Frame) bool) func(frame runtime.Frame) bool {
+	return func(frame runtime.Frame) bool {
+		for _, skip := range skips {
+			if skip(frame) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// TrimPrefix returns a function for Layout.File that removes the prefix from
+// the file of every frame.
+func TrimPrefix(prefix string) func(frame runtime.Frame) string {
+	return func(frame runtime.Frame) string {
+		return strings.TrimPrefix(frame.File, prefix)
+	}
+}
+
+// ModuleRelative is a function for Layout.File that replaces the file of the
+// frame with a path built from the import path of its package. Files in the
+// main module are relative to the root of the module, like
+// "internal/server/server.go", and all other files start with their package
+// path, like "github.com/zeebo/errs/errs.go". Frames without a package, and
+// frames in package main, whose import path does not say where it is in the
+// module, are left unchanged.
+func ModuleRelative(frame runtime.Frame) string {
+	pkg := framePackage(frame)
+	if pkg == "" || pkg == "main" || frame.File == "" {
+		return frame.File
+	}
+	file := path.Join(pkg, filepath.Base(frame.File))
+	if module := mainModule(); module != "" && strings.HasPrefix(file, module+"/") {
+		return file[len(module)+1:]
+	}
+	return file
+}
+
+// mainModulePath caches the path of the main module.
+var (
+	mainModuleOnce sync.Once
+	mainModulePath string
+)
+
+// mainModule returns the path of the main module, if known.
+func mainModule() string {
+	mainModuleOnce.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			mainModulePath = info.Main.Path
+		}
+	})
+	return mainModulePath
+}
+
+// framePackage returns the import path of the package of the function of the
+// frame, or the empty string if it is unknown.
+func framePackage(frame runtime.Frame) string {
+	name := frame.Function
+	// the package path ends at the first dot after the last slash, so that
+	// methods, closures and dots in the domain are handled.
+	slash := strings.LastIndexByte(name, '/')
+	if dot := strings.IndexByte(name[slash+1:], '.'); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package errs
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// inlinedNew is small enough to be inlined into its callers.
+func inlinedNew() error { return New("t") }
+
+func TestFrames(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	frame := func(function, file string) runtime.Frame {
+		return runtime.Frame{Function: function, File: file}
+	}
+
+	t.Run("Last Frame", func(t *testing.T) {
+		lines := strings.Split(fmt.Sprintf("%+v", New("t")), "\n")
+		assert(t, strings.HasPrefix(lines[len(lines)-1], "\truntime.goexit:"), lines)
+	})
+
+	t.Run("Inlined", func(t *testing.T) {
+		out := fmt.Sprintf("%+v", inlinedNew())
+		assert(t, strings.Contains(out, "errs.inlinedNew:"), out)
+		assert(t, strings.Contains(out, "errs.TestFrames.func"), out)
+	})
+
+	t.Run("Skip", func(t *testing.T) {
+		defer SetLayout(FunctionLines)
+
+		SetLayout(Layout{Skip: SkipRuntime})
+		out := fmt.Sprintf("%+v", New("t"))
+		assert(t, !strings.Contains(out, "runtime."), out)
+		assert(t, strings.Contains(out, "testing.tRunner"), out)
+
+		SetLayout(Layout{Skip: SkipAny(SkipStdlib, SkipPackages("github.com/zeebo/errs"))})
+		out = fmt.Sprintf("%+v", New("t"))
+		assert(t, out == "t", out)
+
+		assert(t, SkipStdlib(frame("net/http.(*Server).Serve", "")))
+		assert(t, !SkipStdlib(frame("main.main", "")))
+		assert(t, !SkipStdlib(frame("example.com/foo.Bar", "")))
+		assert(t, SkipPackages("example.com/foo/")(frame("example.com/foo/bar.(*T).M.func1", "")))
+		assert(t, !SkipPackages("example.com/foo")(frame("example.com/foobar.F", "")))
+		assert(t, SkipRuntime(frame("runtime.goexit", "")))
+	})
+
+	t.Run("File", func(t *testing.T) {
+		assert(t, TrimPrefix("/src/")(frame("", "/src/foo.go")) == "foo.go")
+
+		assert(t, ModuleRelative(frame("github.com/zeebo/errs/errstest.Record", "/x/errstest/record.go")) == "errstest/record.go")
+		assert(t, ModuleRelative(frame("github.com/zeebo/errs.New", "/x/errs.go")) == "errs.go")
+		assert(t, ModuleRelative(frame("net/http.(*Server).Serve", "/go/src/net/http/server.go")) == "net/http/server.go")
+		assert(t, ModuleRelative(frame("", "/x/y.go")) == "/x/y.go")
+		assert(t, ModuleRelative(frame("main.main", "/x/cmd/tool/main.go")) == "/x/cmd/tool/main.go")
+		assert(t, ModuleRelative(frame("main.(*server).run.func1", "/x/cmd/tool/server.go")) == "/x/cmd/tool/server.go")
+
+		defer SetLayout(FunctionLines)
+		SetLayout(Layout{Frame: WriteFileLine, File: ModuleRelative})
+		out := fmt.Sprintf("%+v", New("t"))
+		assert(t, strings.HasPrefix(out, "t\n\tframes_test.go:"), out)
+	})
+}
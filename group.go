@@ -3,6 +3,7 @@ package errs
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
 // Group is a list of errors.
@@ -60,26 +61,52 @@ type combinedError []error
 func (group combinedError) Unwrap() []error { return group }
 
 // Error returns error string delimited by semicolons.
-func (group combinedError) Error() string { return fmt.Sprintf("%v", group) }
+func (group combinedError) Error() string {
+	texts := make([]string, len(group))
+	for i, err := range group {
+		texts[i] = err.Error()
+	}
+	return strings.Join(texts, "; ")
+}
 
 // Format handles the formatting of the error. Using a "+" on the format
 // string specifier will cause the errors to be formatted with "+" and
-// delimited by newlines. They are delimited by semicolons otherwise.
+// delimited as configured by the Layout, which defaults to newlines. They are
+// delimited by semicolons otherwise. Using "%#v" formats each error with
+// "%#v". Other verbs, widths and precisions format the message like a
+// string.
 func (group combinedError) Format(f fmt.State, c rune) {
-	delim := "; "
-	if f.Flag(int('+')) {
-		io.WriteString(f, "group:\n--- ")
-		delim = "\n--- "
-	}
-
-	for i, err := range group {
-		if i != 0 {
-			io.WriteString(f, delim)
+	switch {
+	case c == 'v' && f.Flag(int('#')):
+		io.WriteString(f, "errs.combinedError{")
+		for i, err := range group {
+			if i != 0 {
+				io.WriteString(f, ", ")
+			}
+			fmt.Fprintf(f, "%#v", err)
 		}
-		if formatter, ok := err.(fmt.Formatter); ok {
-			formatter.Format(f, c)
-		} else {
-			fmt.Fprintf(f, "%v", err)
+		io.WriteString(f, "}")
+
+	case c == 'v' && f.Flag(int('+')):
+		layout := layoutFor(nil)
+		io.WriteString(f, layout.groupStart())
+		for i, err := range group {
+			if i != 0 {
+				io.WriteString(f, layout.groupSeparator())
+			}
+			if formatter, ok := err.(fmt.Formatter); ok {
+				formatter.Format(f, c)
+			} else {
+				fmt.Fprintf(f, "%v", err)
+			}
 		}
+
+	case c == 'v' || c == 's':
+		fmt.Fprintf(f, directive(f, 's', "-"), group.Error())
+	case c == 'q':
+		fmt.Fprintf(f, directive(f, 'q', "-+#"), group.Error())
+
+	default:
+		fmt.Fprintf(f, directive(f, c, "+-# 0"), group.Error())
 	}
 }
@@ -33,6 +33,22 @@ func TestGroup(t *testing.T) {
 		t.Fatal("expected multiple lines with +v")
 	}
 
+	if fmt.Sprintf("%s", group.Err()) != "alpha; beta" {
+		t.Fatal("expected \"alpha; beta\" with s verb")
+	}
+	if fmt.Sprintf("%q", group.Err()) != `"alpha; beta"` {
+		t.Fatal("expected quoted with q verb")
+	}
+	if fmt.Sprintf("%.5v", group.Err()) != "alpha" {
+		t.Fatal("expected truncation with precision")
+	}
+	if fmt.Sprintf("%x", group.Err()) != fmt.Sprintf("%x", "alpha; beta") {
+		t.Fatal("expected hex with x verb")
+	}
+	if debug := fmt.Sprintf("%#v", group.Err()); !strings.HasPrefix(debug, "errs.combinedError{&errs.errorT{") {
+		t.Fatal("unexpected debug format:", debug)
+	}
+
 	t.Logf("%%v:\n%v", group.Err())
 	t.Logf("%%+v:\n%+v", group.Err())
 
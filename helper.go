@@ -0,0 +1,60 @@
+package errs
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// helpers is a concurrent set of the names of functions marked with Helper,
+// and helperCount is the number of them so that creating errors can cheaply
+// check if there are any.
+var (
+	helpers     sync.Map
+	helperCount int32
+)
+
+// Helper marks the calling function as a helper, like testing.T.Helper. When
+// an error is created, the frames of helper functions at the top of the stack
+// are skipped so that the stack trace starts at the caller of the helper. It
+// is safe to call Helper many times from the same function.
+func Helper() {
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) == 0 {
+		return
+	}
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	if frame.Function == "" {
+		return
+	}
+	if _, loaded := helpers.LoadOrStore(frame.Function, struct{}{}); !loaded {
+		atomic.AddInt32(&helperCount, 1)
+	}
+}
+
+// skipHelpers returns the pcs without any leading pcs that are only in
+// functions marked with Helper.
+func skipHelpers(pcs []uintptr) []uintptr {
+	if atomic.LoadInt32(&helperCount) == 0 {
+		return pcs
+	}
+	for len(pcs) > 0 && isHelper(pcs[0]) {
+		pcs = pcs[1:]
+	}
+	return pcs
+}
+
+// isHelper returns true if every frame of the pc, including any inlined
+// frames, is in a function marked with Helper.
+func isHelper(pc uintptr) bool {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		if _, ok := helpers.Load(frame.Function); !ok {
+			return false
+		}
+		if !more {
+			return true
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package errs
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// originFunction returns the function at the top of the stack of the error.
+func originFunction(err error) string {
+	frame, _ := runtime.CallersFrames(err.(*errorT).Stack()).Next()
+	return frame.Function
+}
+
+//go:noinline
+func depthHelper(class *Class) error {
+	return class.NewDepth(1, "t")
+}
+
+//go:noinline
+func wrapDepthHelper(err error) error {
+	return WrapDepth(1, err)
+}
+
+//go:noinline
+func markedHelper(class *Class) error {
+	Helper()
+	return class.New("t")
+}
+
+//go:noinline
+func nestedHelper(class *Class) error {
+	Helper()
+	return markedHelper(class)
+}
+
+func TestHelper(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+
+	t.Run("Depth", func(t *testing.T) {
+		assert(t, strings.Contains(originFunction(NewDepth(0, "t")), "TestHelper"))
+		assert(t, strings.Contains(originFunction(foo.WrapDepth(0, errors.New("t"))), "TestHelper"))
+
+		err := depthHelper(&foo)
+		assert(t, foo.Has(err))
+		assert(t, strings.Contains(originFunction(err), "TestHelper"), originFunction(err))
+
+		err = wrapDepthHelper(errors.New("t"))
+		assert(t, strings.Contains(originFunction(err), "TestHelper"), originFunction(err))
+	})
+
+	t.Run("Helper", func(t *testing.T) {
+		err := markedHelper(&foo)
+		assert(t, foo.Has(err))
+		assert(t, strings.Contains(originFunction(err), "TestHelper"), originFunction(err))
+
+		err = nestedHelper(&foo)
+		assert(t, strings.Contains(originFunction(err), "TestHelper"), originFunction(err))
+	})
+}
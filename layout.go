@@ -0,0 +1,129 @@
+package errs
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync/atomic"
+)
+
+// Layout controls how errors are written when formatted with "%+v". The zero
+// value of any field uses the default for that field.
+type Layout struct {
+	// Separator is written between the name of the class and the message.
+	// The default is the separator of the class, which defaults to ": ".
+	Separator string
+
+	// Frame writes a single frame of the stack trace. Every frame is preceded
+	// by a newline. The default writes the function and line.
+	Frame func(w io.Writer, frame runtime.Frame)
+
+	// Skip returns true for frames that should not be written. See
+	// SkipPackages, SkipStdlib, SkipRuntime and SkipAny. The default writes
+	// every frame.
+	Skip func(frame runtime.Frame) bool
+
+	// File rewrites the file of every frame before it is written, for example
+	// with ModuleRelative or TrimPrefix. The default leaves files unchanged.
+	File func(frame runtime.Frame) string
+
+	// GroupStart is written before the first error of a group and
+	// GroupSeparator is written between the errors of a group. The defaults
+	// are "group:\n--- " and "\n--- ".
+	GroupStart     string
+	GroupSeparator string
+}
+
+// The built in layouts.
+var (
+	// FunctionLines writes each frame as a tab, the function and the line. It is
+	// the default.
+	FunctionLines = Layout{Frame: WriteFunctionLine}
+
+	// FileLines writes each frame as a tab, the file and the line.
+	FileLines = Layout{Frame: WriteFileLine}
+
+	// Traceback writes each frame in the same format as the Go runtime uses
+	// when printing a goroutine during a panic so that tooling that parses
+	// those can parse errors too.
+	Traceback = Layout{Frame: WriteTraceback}
+)
+
+// WriteFunctionLine writes a tab, the function and the line of the frame.
+func WriteFunctionLine(w io.Writer, frame runtime.Frame) {
+	fmt.Fprintf(w, "\t%s:%d", frame.Function, frame.Line)
+}
+
+// WriteFileLine writes a tab, the file and the line of the frame.
+func WriteFileLine(w io.Writer, frame runtime.Frame) {
+	fmt.Fprintf(w, "\t%s:%d", frame.File, frame.Line)
+}
+
+// WriteTraceback writes the frame like the Go runtime does in a traceback: the
+// function on one line and the file, line and pc offset on the next. Like the
+// runtime, the offset is left out for inlined frames.
+func WriteTraceback(w io.Writer, frame runtime.Frame) {
+	fmt.Fprintf(w, "%s(...)\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	if frame.Func != nil && frame.PC >= frame.Entry {
+		fmt.Fprintf(w, " +0x%x", frame.PC-frame.Entry)
+	}
+}
+
+// defaultLayout holds the *Layout set with SetLayout.
+var defaultLayout atomic.Value
+
+// SetLayout sets the layout used for errors whose class does not have one.
+func SetLayout(layout Layout) {
+	defaultLayout.Store(&layout)
+}
+
+// SetLayout sets the layout used for errors in this class.
+func (c *Class) SetLayout(layout Layout) {
+	c.updateOptions(func(opts *classOptions) { opts.layout = &layout })
+}
+
+// layoutFor returns the layout used by errors in the class.
+func layoutFor(class *Class) *Layout {
+	if layout := class.options().layout; layout != nil {
+		return layout
+	}
+	layout, _ := defaultLayout.Load().(*Layout)
+	if layout == nil {
+		return &FunctionLines
+	}
+	return layout
+}
+
+// writeFrame writes a newline and the frame with the layout unless the frame
+// is skipped.
+func (l *Layout) writeFrame(w io.Writer, frame runtime.Frame) {
+	if l.Skip != nil && l.Skip(frame) {
+		return
+	}
+	if l.File != nil {
+		frame.File = l.File(frame)
+	}
+
+	io.WriteString(w, "\n")
+	if l.Frame == nil {
+		WriteFunctionLine(w, frame)
+	} else {
+		l.Frame(w, frame)
+	}
+}
+
+// groupStart returns the string written before the first error of a group.
+func (l *Layout) groupStart() string {
+	if l.GroupStart == "" {
+		return "group:\n--- "
+	}
+	return l.GroupStart
+}
+
+// groupSeparator returns the string written between the errors of a group.
+func (l *Layout) groupSeparator() string {
+	if l.GroupSeparator == "" {
+		return "\n--- "
+	}
+	return l.GroupSeparator
+}
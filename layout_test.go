@@ -0,0 +1,52 @@
+package errs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	bar := Class("bar")
+	bar.SetLayout(Layout{Separator: " - ", Frame: WriteFileLine})
+
+	t.Run("Default", func(t *testing.T) {
+		out := fmt.Sprintf("%+v", foo.New("t"))
+		assert(t, regexp.MustCompile(`^foo: t\n\t\S+TestLayout\.func\d+:\d+\n`).MatchString(out), out)
+	})
+
+	t.Run("Class", func(t *testing.T) {
+		err := bar.New("t")
+		out := fmt.Sprintf("%+v", err)
+		assert(t, regexp.MustCompile(`^bar - t\n\t\S+layout_test\.go:\d+\n`).MatchString(out), out)
+		assert(t, err.Error() == "bar: t", err.Error())
+	})
+
+	t.Run("Global", func(t *testing.T) {
+		SetLayout(Layout{
+			Frame:          WriteTraceback,
+			GroupStart:     "errors:\n",
+			GroupSeparator: "\n\n",
+		})
+		defer SetLayout(FunctionLines)
+
+		out := fmt.Sprintf("%+v", foo.New("t"))
+		assert(t, regexp.MustCompile(`^foo: t\n\S+TestLayout\.func\d+\(\.\.\.\)\n\t\S+layout_test\.go:\d+ \+0x[0-9a-f]+\n`).MatchString(out), out)
+
+		out = fmt.Sprintf("%+v", Combine(foo.New("a"), foo.New("b")))
+		assert(t, strings.HasPrefix(out, "errors:\nfoo: a\n"), out)
+		assert(t, strings.Contains(out, "\n\nfoo: b\n"), out)
+
+		out = fmt.Sprintf("%+v", bar.New("t"))
+		assert(t, strings.HasPrefix(out, "bar - t\n\t"), out)
+	})
+}
@@ -0,0 +1,130 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Catalog holds the translations of a class into some language.
+type Catalog struct {
+	// Name is the translated name of the class. If empty, the name of the
+	// class is used.
+	Name string
+
+	// Messages maps the format strings passed to New to translated format
+	// strings. The translations are formatted with the original arguments, so
+	// they should use the same verbs, possibly reordered with explicit
+	// argument indexes like %[2]s.
+	Messages map[string]string
+}
+
+// SetCatalog sets the catalog used to localize errors in this class into the
+// language. The catalog should not be modified after it is set.
+func (c *Class) SetCatalog(lang string, catalog Catalog) {
+	c.updateOptions(func(opts *classOptions) {
+		catalogs := make(map[string]Catalog, len(opts.catalogs)+1)
+		for lang, catalog := range opts.catalogs {
+			catalogs[lang] = catalog
+		}
+		catalogs[lang] = catalog
+		opts.catalogs = catalogs
+	})
+}
+
+// catalog returns the catalog for the language, falling back to the base
+// language if lang has a region like "pt-BR".
+func (c *Class) catalog(lang string) (Catalog, bool) {
+	catalogs := c.options().catalogs
+	if catalog, ok := catalogs[lang]; ok {
+		return catalog, true
+	}
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		catalog, ok := catalogs[lang[:i]]
+		return catalog, ok
+	}
+	return Catalog{}, false
+}
+
+// Localize returns the message of the error translated into the language
+// using the catalogs set on its classes. Messages created by New are looked
+// up by their format string in the catalogs of the classes wrapping them,
+// innermost first, and are formatted with their original arguments. Anything
+// without a translation is rendered as it would be by Error.
+func Localize(err error, lang string) string {
+	return localize(err, lang, nil)
+}
+
+// localize translates the error using the catalogs of the enclosing classes,
+// which are ordered innermost first.
+func localize(err error, lang string, enclosing []*Class) string {
+	if err == nil {
+		return ""
+	}
+
+	if errt, ok := asErrorT(err); ok {
+		err = errt
+	}
+
+	switch e := err.(type) {
+	case *errorT:
+		if e.class != nil {
+			enclosing = append([]*Class{e.class}, enclosing...)
+		}
+		text := localize(e.err, lang, enclosing)
+
+		name, _ := e.Name()
+		if catalog, ok := e.class.catalog(lang); ok && catalog.Name != "" {
+			name = catalog.Name
+		}
+
+		var sb strings.Builder
+		writeText(&sb, e.class, name, text, false)
+		return sb.String()
+
+	case *message:
+		format := e.format
+		for _, class := range enclosing {
+			catalog, ok := class.catalog(lang)
+			if translated, found := catalog.Messages[e.format]; ok && found {
+				format = translated
+				break
+			}
+		}
+
+		args := make([]interface{}, len(e.args))
+		for i, arg := range e.args {
+			if err, ok := arg.(error); ok {
+				args[i] = localized{err: err, lang: lang}
+			} else {
+				args[i] = arg
+			}
+		}
+		return fmt.Errorf(format, args...).Error()
+
+	case *publicError:
+		return localize(e.err, lang, enclosing)
+
+	case *causeError:
+		return localize(e.cause, lang, enclosing)
+
+	case combinedError:
+		texts := make([]string, len(e))
+		for i, err := range e {
+			texts[i] = localize(err, lang, enclosing)
+		}
+		return strings.Join(texts, "; ")
+
+	default:
+		return err.Error()
+	}
+}
+
+// localized wraps an error argument so that it renders translated while still
+// being usable with the %w verb.
+type localized struct {
+	err  error
+	lang string
+}
+
+// Error returns the translated message of the wrapped error.
+func (l localized) Error() string { return Localize(l.err, l.lang) }
@@ -0,0 +1,55 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	notFound := Class("not found")
+	notFound.SetCatalog("fr", Catalog{
+		Name: "introuvable",
+		Messages: map[string]string{
+			"user %d not found":     "utilisateur %d introuvable",
+			"user %d in %s missing": "dans %[2]s, utilisateur %[1]d manquant",
+			"lookup: %w":            "recherche : %w",
+		},
+	})
+	outer := Class("outer")
+
+	t.Run("Translated", func(t *testing.T) {
+		err := notFound.New("user %d not found", 42)
+		assert(t, Localize(err, "fr") == "introuvable: utilisateur 42 introuvable", Localize(err, "fr"))
+		assert(t, Localize(err, "fr-CA") == "introuvable: utilisateur 42 introuvable", Localize(err, "fr-CA"))
+
+		err = notFound.New("user %d in %s missing", 42, "paris")
+		assert(t, Localize(err, "fr") == "introuvable: dans paris, utilisateur 42 manquant", Localize(err, "fr"))
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		err := notFound.New("user %d not found", 42)
+		assert(t, Localize(err, "de") == err.Error(), Localize(err, "de"))
+
+		err = notFound.New("no translation %d", 42)
+		assert(t, Localize(err, "fr") == "introuvable: no translation 42", Localize(err, "fr"))
+
+		err = errors.New("foreign")
+		assert(t, Localize(err, "fr") == "foreign")
+		assert(t, Localize(nil, "fr") == "")
+	})
+
+	t.Run("Chain", func(t *testing.T) {
+		err := outer.Wrap(notFound.Wrap(New("lookup: %w", notFound.New("user %d not found", 42))))
+		assert(t, Localize(err, "fr") == "outer: introuvable: recherche : introuvable: utilisateur 42 introuvable", Localize(err, "fr"))
+
+		group := Combine(notFound.New("user %d not found", 1), errors.New("foreign"))
+		assert(t, Localize(group, "fr") == "introuvable: utilisateur 1 introuvable; foreign", Localize(group, "fr"))
+	})
+}
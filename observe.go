@@ -0,0 +1,55 @@
+package errs
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// observer wraps a function registered with Observe so that it can be
+// removed by identity.
+type observer struct{ fn func(err error) }
+
+// observers holds a []*observer. it is replaced wholesale on every change so
+// that creating an error only has to do an atomic load.
+var (
+	observersMu sync.Mutex
+	observers   atomic.Value
+)
+
+// Observe registers fn to be called with every error created by this package,
+// including errors that have a new class added to them. It returns a function
+// that unregisters fn. Since fn is called inline every time an error is
+// created, it must be safe for concurrent use and should return quickly.
+func Observe(fn func(err error)) (unregister func()) {
+	obs := &observer{fn: fn}
+
+	observersMu.Lock()
+	defer observersMu.Unlock()
+
+	current, _ := observers.Load().([]*observer)
+	next := make([]*observer, 0, len(current)+1)
+	next = append(next, current...)
+	observers.Store(append(next, obs))
+
+	return func() {
+		observersMu.Lock()
+		defer observersMu.Unlock()
+
+		current, _ := observers.Load().([]*observer)
+		next := make([]*observer, 0, len(current))
+		for _, o := range current {
+			if o != obs {
+				next = append(next, o)
+			}
+		}
+		observers.Store(next)
+	}
+}
+
+// notify calls all of the registered observers with the error.
+func notify(err error) {
+	current, _ := observers.Load().([]*observer)
+	for _, o := range current {
+		o.fn(err)
+	}
+}
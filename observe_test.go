@@ -0,0 +1,37 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestObserve(t *testing.T) {
+	foo := Class("foo")
+	bar := Class("bar")
+
+	var seen []error
+	unregister := Observe(func(err error) { seen = append(seen, err) })
+
+	err := foo.New("t")
+	_ = foo.Wrap(err)
+	_ = bar.Wrap(err)
+	_ = Wrap(errors.New("t"))
+	_ = foo.Wrap(nil)
+
+	if len(seen) != 3 {
+		t.Fatal("expected 3 observed errors, got", len(seen))
+	}
+	if seen[0] != err {
+		t.Fatal("expected first observed error to be the created error")
+	}
+	if !bar.Has(seen[1]) {
+		t.Fatal("expected second observed error to have bar")
+	}
+
+	unregister()
+	_ = foo.New("t")
+
+	if len(seen) != 3 {
+		t.Fatal("expected no errors observed after unregister")
+	}
+}
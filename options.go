@@ -0,0 +1,47 @@
+package errs
+
+import "sync"
+
+// classOptions is the configuration associated with a class. Stored options
+// are never modified: updates store a modified copy so that readers do not
+// need to lock.
+type classOptions struct {
+	public    string
+	catalogs  map[string]Catalog
+	code      string
+	layout    *Layout
+	nameStyle NameStyle
+	separator string
+}
+
+// defaultOptions are the options of classes that have not been configured.
+var defaultOptions classOptions
+
+// classOptionsMu serializes updates to the classOptionsMap, which is a
+// concurrent map[*Class]*classOptions. it is expected to be frequently read,
+// with a one time initial set of writes.
+var (
+	classOptionsMu  sync.Mutex
+	classOptionsMap sync.Map
+)
+
+// options returns the configuration for the class.
+func (c *Class) options() *classOptions {
+	if c != nil {
+		if opts, ok := classOptionsMap.Load(c); ok {
+			return opts.(*classOptions)
+		}
+	}
+	return &defaultOptions
+}
+
+// updateOptions stores the configuration for the class after it has been
+// modified by fn.
+func (c *Class) updateOptions(fn func(opts *classOptions)) {
+	classOptionsMu.Lock()
+	defer classOptionsMu.Unlock()
+
+	opts := *c.options()
+	fn(&opts)
+	classOptionsMap.Store(c, &opts)
+}
@@ -0,0 +1,87 @@
+package errs
+
+// The behavioral interfaces that errors created by this package preserve from
+// the errors they wrap, so that code type asserting for them instead of using
+// errors.As keeps working after an error is wrapped.
+type (
+	timeouter interface{ Timeout() bool }
+	temporary interface{ Temporary() bool }
+	coder     interface{ Code() int }
+)
+
+// preserve returns the error with the same Timeout, Temporary and Code methods
+// as the error it wraps, if it has any of them.
+func preserve(e *errorT) error {
+	_, isTimeout := e.err.(timeouter)
+	_, isTemporary := e.err.(temporary)
+	_, isCoder := e.err.(coder)
+
+	switch {
+	case isTimeout && isTemporary && isCoder:
+		return timeoutTemporaryCodeErrorT{e}
+	case isTimeout && isTemporary:
+		return timeoutTemporaryErrorT{e}
+	case isTimeout && isCoder:
+		return timeoutCodeErrorT{e}
+	case isTemporary && isCoder:
+		return temporaryCodeErrorT{e}
+	case isTimeout:
+		return timeoutErrorT{e}
+	case isTemporary:
+		return temporaryErrorT{e}
+	case isCoder:
+		return codeErrorT{e}
+	default:
+		return e
+	}
+}
+
+// timeout returns the result of the Timeout method of the wrapped error.
+func (e *errorT) timeout() bool { return e.err.(timeouter).Timeout() }
+
+// temporary returns the result of the Temporary method of the wrapped error.
+func (e *errorT) temporary() bool { return e.err.(temporary).Temporary() }
+
+// code returns the result of the Code method of the wrapped error.
+func (e *errorT) code() int { return e.err.(coder).Code() }
+
+// timeoutErrorT preserves the Timeout method.
+type timeoutErrorT struct{ *errorT }
+
+func (e timeoutErrorT) Timeout() bool { return e.timeout() }
+
+// temporaryErrorT preserves the Temporary method.
+type temporaryErrorT struct{ *errorT }
+
+func (e temporaryErrorT) Temporary() bool { return e.temporary() }
+
+// codeErrorT preserves the Code method.
+type codeErrorT struct{ *errorT }
+
+func (e codeErrorT) Code() int { return e.code() }
+
+// timeoutTemporaryErrorT preserves the Timeout and Temporary methods, like
+// those of net.Error.
+type timeoutTemporaryErrorT struct{ *errorT }
+
+func (e timeoutTemporaryErrorT) Timeout() bool   { return e.timeout() }
+func (e timeoutTemporaryErrorT) Temporary() bool { return e.temporary() }
+
+// timeoutCodeErrorT preserves the Timeout and Code methods.
+type timeoutCodeErrorT struct{ *errorT }
+
+func (e timeoutCodeErrorT) Timeout() bool { return e.timeout() }
+func (e timeoutCodeErrorT) Code() int     { return e.code() }
+
+// temporaryCodeErrorT preserves the Temporary and Code methods.
+type temporaryCodeErrorT struct{ *errorT }
+
+func (e temporaryCodeErrorT) Temporary() bool { return e.temporary() }
+func (e temporaryCodeErrorT) Code() int       { return e.code() }
+
+// timeoutTemporaryCodeErrorT preserves the Timeout, Temporary and Code methods.
+type timeoutTemporaryCodeErrorT struct{ *errorT }
+
+func (e timeoutTemporaryCodeErrorT) Timeout() bool   { return e.timeout() }
+func (e timeoutTemporaryCodeErrorT) Temporary() bool { return e.temporary() }
+func (e timeoutTemporaryCodeErrorT) Code() int       { return e.code() }
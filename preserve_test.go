@@ -0,0 +1,96 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+)
+
+type onlyTimeout struct{}
+
+func (onlyTimeout) Error() string { return "timeout" }
+func (onlyTimeout) Timeout() bool { return true }
+
+type onlyTemporary struct{}
+
+func (onlyTemporary) Error() string   { return "temporary" }
+func (onlyTemporary) Temporary() bool { return true }
+
+type statusError struct{ code int }
+
+func (e statusError) Error() string { return fmt.Sprintf("status %d", e.code) }
+func (e statusError) Code() int     { return e.code }
+
+type everything struct{ statusError }
+
+func (everything) Timeout() bool   { return false }
+func (everything) Temporary() bool { return true }
+
+func TestPreserve(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	bar := Class("bar")
+
+	isTimeout := func(err error) bool {
+		t, ok := err.(interface{ Timeout() bool })
+		return ok && t.Timeout()
+	}
+	isTemporary := func(err error) bool {
+		t, ok := err.(interface{ Temporary() bool })
+		return ok && t.Temporary()
+	}
+	code := func(err error) int {
+		if c, ok := err.(interface{ Code() int }); ok {
+			return c.Code()
+		}
+		return -1
+	}
+
+	t.Run("Net Error", func(t *testing.T) {
+		err := foo.Wrap(&net.DNSError{Err: "t", IsTimeout: true})
+		netErr, ok := err.(net.Error)
+		assert(t, ok)
+		assert(t, netErr.Timeout())
+		assert(t, foo.Has(err))
+		assert(t, err.Error() == "foo: lookup : t", err.Error())
+	})
+
+	t.Run("Each", func(t *testing.T) {
+		assert(t, isTimeout(foo.Wrap(onlyTimeout{})))
+		assert(t, !isTemporary(foo.Wrap(onlyTimeout{})))
+		assert(t, code(foo.Wrap(onlyTimeout{})) == -1)
+
+		assert(t, isTemporary(foo.Wrap(onlyTemporary{})))
+		assert(t, !isTimeout(foo.Wrap(onlyTemporary{})))
+
+		assert(t, code(foo.Wrap(statusError{code: 404})) == 404)
+		assert(t, !isTimeout(foo.Wrap(statusError{code: 404})))
+
+		err := foo.Wrap(everything{statusError{code: 503}})
+		assert(t, code(err) == 503 && isTemporary(err) && !isTimeout(err))
+		_, ok := err.(interface{ Timeout() bool })
+		assert(t, ok)
+
+		_, ok = foo.Wrap(errors.New("t")).(interface{ Timeout() bool })
+		assert(t, !ok)
+	})
+
+	t.Run("Chain", func(t *testing.T) {
+		err := bar.Wrap(foo.Wrap(statusError{code: 404}))
+		assert(t, code(err) == 404)
+		assert(t, foo.Has(err) && bar.Has(err))
+		assert(t, len(Classes(err)) == 2)
+		assert(t, bar.Wrap(err) == err)
+		assert(t, errors.Is(err, foo.Instance()))
+
+		name, ok := err.(Namer).Name()
+		assert(t, ok && name == "bar")
+	})
+}
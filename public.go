@@ -0,0 +1,73 @@
+package errs
+
+import "fmt"
+
+// genericPublicMessage is returned by PublicMessage when the error has no
+// public message.
+const genericPublicMessage = "internal error"
+
+// Public returns an error that behaves exactly like err, but additionally
+// carries a message that is safe to show to users. Use PublicMessage to get
+// it back out. Public returns nil if err is nil.
+func Public(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &publicError{err: err, message: message}
+}
+
+// SetPublic sets the public message used by PublicMessage for errors in this
+// class that do not have a more specific one.
+func (c *Class) SetPublic(message string) {
+	c.updateOptions(func(opts *classOptions) { opts.public = message })
+}
+
+// PublicMessage returns the outermost public message of the error, whether it
+// was set with Public or with (*Class).SetPublic. If there is none, it returns
+// a generic message that does not leak any details. It returns the empty
+// string if err is nil.
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	message := genericPublicMessage
+	IsFunc(err, func(err error) bool {
+		if errt, ok := asErrorT(err); ok {
+			err = errt
+		}
+		switch e := err.(type) {
+		case *publicError:
+			message = e.message
+			return true
+		case *errorT:
+			if public := e.class.options().public; public != "" {
+				message = public
+				return true
+			}
+		}
+		return false
+	})
+	return message
+}
+
+// publicError associates a public message with an error.
+type publicError struct {
+	err     error
+	message string
+}
+
+// Error returns the message of the underlying error, not the public message.
+func (e *publicError) Error() string { return e.err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *publicError) Unwrap() error { return e.err }
+
+// Format formats the underlying error.
+func (e *publicError) Format(f fmt.State, c rune) {
+	if formatter, ok := e.err.(fmt.Formatter); ok {
+		formatter.Format(f, c)
+	} else {
+		fmt.Fprintf(f, directive(f, c, "+-# 0"), e.err)
+	}
+}
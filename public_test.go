@@ -0,0 +1,54 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPublic(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	bar := Class("bar")
+	bar.SetPublic("bar happened")
+
+	t.Run("Generic", func(t *testing.T) {
+		assert(t, PublicMessage(nil) == "")
+		assert(t, PublicMessage(errors.New("secret detail")) == genericPublicMessage)
+		assert(t, PublicMessage(foo.New("secret detail")) == genericPublicMessage)
+	})
+
+	t.Run("Public", func(t *testing.T) {
+		err := Public(foo.New("secret detail"), "try again later")
+
+		assert(t, Public(nil, "t") == nil)
+		assert(t, PublicMessage(err) == "try again later")
+		assert(t, err.Error() == "foo: secret detail", err.Error())
+		assert(t, foo.Has(err))
+		assert(t, strings.Contains(fmt.Sprintf("%+v", err), "\n"))
+
+		plain := Public(errors.New("x"), "y")
+		assert(t, fmt.Sprintf("%q", plain) == `"x"`, fmt.Sprintf("%q", plain))
+		assert(t, fmt.Sprintf("%3s|%-3v|", plain, plain) == "  x|x  |", fmt.Sprintf("%3s|%-3v|", plain, plain))
+		assert(t, Unredacted(Public(New("%s", Secret("x")), "t")) == "x")
+	})
+
+	t.Run("Class", func(t *testing.T) {
+		assert(t, PublicMessage(bar.New("secret detail")) == "bar happened")
+		assert(t, PublicMessage(foo.Wrap(bar.New("secret detail"))) == "bar happened")
+	})
+
+	t.Run("Outermost", func(t *testing.T) {
+		err := Public(bar.New("secret detail"), "specific")
+		assert(t, PublicMessage(err) == "specific")
+		assert(t, PublicMessage(bar.Wrap(Public(foo.New("t"), "specific"))) == "bar happened")
+		assert(t, PublicMessage(Public(Public(foo.New("t"), "inner"), "outer")) == "outer")
+	})
+}
@@ -0,0 +1,84 @@
+package errs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// redacted is what secrets are rendered as.
+const redacted = "[REDACTED]"
+
+// secret is an argument that is redacted when formatted.
+type secret struct{ value interface{} }
+
+// Format writes the redacted placeholder for every verb and flag.
+func (s secret) Format(f fmt.State, c rune) { io.WriteString(f, redacted) }
+
+// Secret wraps an argument to New or (*Class).New so that it is redacted
+// whenever the error is formatted, including with "%+v". Use Unredacted to
+// render an error with its secrets revealed. For example:
+//
+//	errs.New("bad token %q", errs.Secret(token))
+//
+// Secrets cannot be used with the %w verb.
+func Secret(v interface{}) interface{} { return secret{value: v} }
+
+// Unredacted returns the message of the error with any secrets revealed. It
+// should only be used for sinks that are allowed to see sensitive data. Errors
+// not created by this package are rendered with their Error method, so secrets
+// that were formatted into them are still redacted.
+func Unredacted(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if errt, ok := asErrorT(err); ok {
+		err = errt
+	}
+
+	switch e := err.(type) {
+	case *errorT:
+		var sb strings.Builder
+		name, _ := e.Name()
+		writeText(&sb, e.class, name, Unredacted(e.err), false)
+		return sb.String()
+
+	case *message:
+		args := make([]interface{}, len(e.args))
+		for i, arg := range e.args {
+			switch arg := arg.(type) {
+			case secret:
+				args[i] = arg.value
+			case error:
+				args[i] = revealed{arg}
+			default:
+				args[i] = arg
+			}
+		}
+		return fmt.Errorf(e.format, args...).Error()
+
+	case *publicError:
+		return Unredacted(e.err)
+
+	case *causeError:
+		return Unredacted(e.cause)
+
+	case combinedError:
+		texts := make([]string, len(e))
+		for i, err := range e {
+			texts[i] = Unredacted(err)
+		}
+		return strings.Join(texts, "; ")
+
+	default:
+		return err.Error()
+	}
+}
+
+// revealed wraps an error argument so that it renders unredacted while still
+// being usable with the %w verb.
+type revealed struct{ err error }
+
+// Error returns the unredacted message of the wrapped error.
+func (r revealed) Error() string { return Unredacted(r.err) }
@@ -0,0 +1,52 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSecret(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	token := "hunter2"
+
+	t.Run("Redacted", func(t *testing.T) {
+		err := foo.New("bad token %q for %s", Secret(token), "zeebo")
+
+		assert(t, err.Error() == "foo: bad token [REDACTED] for zeebo", err.Error())
+		assert(t, !strings.Contains(fmt.Sprintf("%+v", err), token))
+		assert(t, !strings.Contains(fmt.Sprintf("%#v", Secret(token)), token))
+	})
+
+	t.Run("Unredacted", func(t *testing.T) {
+		err := foo.New("bad token %q for %s", Secret(token), "zeebo")
+
+		assert(t, Unredacted(err) == `foo: bad token "hunter2" for zeebo`, Unredacted(err))
+		assert(t, Unredacted(nil) == "")
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		inner := New("token %s", Secret(token))
+		err := foo.Wrap(New("outer: %w", inner))
+
+		assert(t, err.Error() == "foo: outer: token [REDACTED]", err.Error())
+		assert(t, Unredacted(err) == "foo: outer: token hunter2", Unredacted(err))
+		assert(t, foo.Has(err))
+
+		group := Combine(inner, New("other %v", Secret(1)))
+		assert(t, group.Error() == "token [REDACTED]; other [REDACTED]", group.Error())
+		assert(t, Unredacted(group) == "token hunter2; other 1", Unredacted(group))
+	})
+
+	t.Run("Foreign", func(t *testing.T) {
+		err := fmt.Errorf("foreign: %v", New("token %s", Secret(token)))
+		assert(t, Unredacted(err) == "foreign: token [REDACTED]", Unredacted(err))
+	})
+}
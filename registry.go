@@ -0,0 +1,76 @@
+package errs
+
+import (
+	"sort"
+	"sync"
+)
+
+// registry holds the registered classes by name.
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]*Class)
+)
+
+// Register adds the class to the registry so that it can be found by
+// LookupClass and AllClasses. Registering the same class more than once does
+// nothing, but it is an error to register a different class with the same
+// name, even from a different package, or a different class with the same
+// code. It is also an error to register a nil class.
+func Register(class *Class) error {
+	if class == nil {
+		return New("cannot register a nil class")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	name := string(*class)
+	if existing, ok := registry[name]; ok && existing != class {
+		return New("class %q is already registered", name)
+	}
+	if code := class.options().code; code != "" {
+		for _, existing := range registry {
+			if existing != class && existing.options().code == code {
+				return New("class %q has the same code %q as class %q",
+					name, code, string(*existing))
+			}
+		}
+	}
+	registry[name] = class
+	return nil
+}
+
+// NewClass constructs and registers a class with the given name. It panics if
+// a class with the same name is already registered, so it is best used to
+// initialize package level variables. For example:
+//
+//	var Unauthorized = errs.NewClass("unauthorized")
+func NewClass(name string) *Class {
+	class := Class(name)
+	if err := Register(&class); err != nil {
+		panic(err)
+	}
+	return &class
+}
+
+// LookupClass returns the registered class with the given name, if any.
+func LookupClass(name string) (*Class, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	class, ok := registry[name]
+	return class, ok
+}
+
+// AllClasses returns every registered class sorted by name.
+func AllClasses() []*Class {
+	registryMu.Lock()
+	classes := make([]*Class, 0, len(registry))
+	for _, class := range registry {
+		classes = append(classes, class)
+	}
+	registryMu.Unlock()
+
+	sort.Slice(classes, func(i, j int) bool { return *classes[i] < *classes[j] })
+	return classes
+}
@@ -0,0 +1,81 @@
+package errs
+
+import "testing"
+
+func TestRegistry(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	defer snapshotRegistry()()
+
+	registered := Class("registry_test registered")
+	assert(t, Register(&registered) == nil)
+	assert(t, Register(&registered) == nil)
+
+	t.Run("Duplicate", func(t *testing.T) {
+		duplicate := Class("registry_test registered")
+		err := Register(&duplicate)
+		assert(t, err != nil)
+		t.Log(err)
+
+		class, ok := LookupClass("registry_test registered")
+		assert(t, ok && class == &registered)
+	})
+
+	t.Run("NewClass", func(t *testing.T) {
+		class := NewClass("registry_test new")
+		assert(t, *class == "registry_test new")
+		assert(t, class.Has(class.New("t")))
+
+		found, ok := LookupClass("registry_test new")
+		assert(t, ok && found == class)
+
+		defer func() { assert(t, recover() != nil, "expected panic") }()
+		NewClass("registry_test new")
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		assert(t, Register(nil) != nil)
+	})
+
+	t.Run("Lookup Missing", func(t *testing.T) {
+		class, ok := LookupClass("registry_test missing")
+		assert(t, !ok && class == nil)
+	})
+
+	t.Run("AllClasses", func(t *testing.T) {
+		classes := AllClasses()
+		var found int
+		for i, class := range classes {
+			if i > 0 {
+				assert(t, *classes[i-1] < *class)
+			}
+			if class == &registered || *class == "registry_test new" {
+				found++
+			}
+		}
+		assert(t, found == 2, found)
+	})
+}
+
+// snapshotRegistry copies the registry and returns a function that restores
+// it, so that tests can register classes without affecting later runs.
+func snapshotRegistry() (restore func()) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	saved := make(map[string]*Class, len(registry))
+	for name, class := range registry {
+		saved[name] = class
+	}
+	return func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+
+		registry = saved
+	}
+}
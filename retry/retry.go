@@ -0,0 +1,181 @@
+// Package retry retries operations based on the classes of their errors
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// Clock is used to wait between attempts. It can be replaced in tests.
+type Clock interface {
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer sends the time on its channel once it fires, like a time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// realClock is the Clock that uses the time package.
+type realClock struct{}
+
+// NewTimer calls time.NewTimer.
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+// realTimer is the Timer that uses the time package.
+type realTimer struct{ timer *time.Timer }
+
+// C returns the channel of the timer.
+func (t realTimer) C() <-chan time.Time { return t.timer.C }
+
+// Stop stops the timer.
+func (t realTimer) Stop() bool { return t.timer.Stop() }
+
+// Policy decides which errors are retried and how long to wait between
+// attempts. The zero value of any field uses the default for that field.
+type Policy struct {
+	// Attempts is the maximum number of attempts, including the first. The
+	// default is 3.
+	Attempts int
+
+	// Classes are the classes of errors that are retried.
+	Classes []*errs.Class
+
+	// Temporary causes errors reporting true from a Temporary() or Timeout()
+	// method, like net.Error, to be retried.
+	Temporary bool
+
+	// Initial is the delay after the first attempt, which is multiplied by
+	// Multiplier after every attempt up to Max. The defaults are 100
+	// milliseconds, 2 and 10 seconds.
+	Initial    time.Duration
+	Multiplier float64
+	Max        time.Duration
+
+	// Jitter is the fraction, from 0 to 1, of every delay that is randomly
+	// removed to spread out retries. Values outside of that range are
+	// clamped to it. The default is no jitter.
+	Jitter float64
+
+	// RetryAfter overrides the delay after errors in the class. If an error
+	// has multiple such classes, the outermost one is used. It does not make
+	// errors retryable, so the classes should also be listed in Classes.
+	RetryAfter map[*errs.Class]time.Duration
+
+	// Clock is used to wait between attempts. The default uses the time
+	// package.
+	Clock Clock
+
+	// Rand returns a random number in [0, 1) for the jitter. The default uses
+	// the math/rand package.
+	Rand func() float64
+}
+
+// Retryable returns true if the error should be retried by the policy.
+func (p Policy) Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, class := range p.Classes {
+		if class.Has(err) {
+			return true
+		}
+	}
+	if p.Temporary {
+		return errs.IsFunc(err, func(err error) bool {
+			if t, ok := err.(interface{ Temporary() bool }); ok && t.Temporary() {
+				return true
+			}
+			t, ok := err.(interface{ Timeout() bool })
+			return ok && t.Timeout()
+		})
+	}
+	return false
+}
+
+// Delay returns how long to wait after the attempt, numbered from 1, failed
+// with the error.
+func (p Policy) Delay(attempt int, err error) time.Duration {
+	for _, class := range errs.Classes(err) {
+		if delay, ok := p.RetryAfter[class]; ok {
+			return delay
+		}
+	}
+
+	initial, multiplier, max := p.Initial, p.Multiplier, p.Max
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	if max <= 0 {
+		max = 10 * time.Second
+	}
+
+	delay := float64(initial)
+	for i := 1; i < attempt && delay < float64(max); i++ {
+		delay *= multiplier
+	}
+	if delay > float64(max) {
+		delay = float64(max)
+	}
+
+	if jitter := p.Jitter; jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		random := p.Rand
+		if random == nil {
+			random = rand.Float64
+		}
+		delay -= jitter * random() * delay
+	}
+	return time.Duration(delay)
+}
+
+// Do calls fn until it succeeds, it returns an error the policy does not
+// retry, the attempts run out or the context is done. If it does not succeed,
+// it returns an error combining the error from every attempt, followed by the
+// error of the context if it ended the retries.
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	attempts := policy.Attempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+	clock := policy.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	var group errs.Group
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			group.Add(err)
+			return group.Err()
+		}
+
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		group.Add(err)
+
+		if attempt >= attempts || !policy.Retryable(err) {
+			return group.Err()
+		}
+
+		timer := clock.NewTimer(policy.Delay(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			group.Add(ctx.Err())
+			return group.Err()
+		case <-timer.C():
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// fakeClock records the delays waited for and fires immediately, unless it
+// is blocked, and counts the timers stopped.
+type fakeClock struct {
+	delays  []time.Duration
+	blocked bool
+	stopped int
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	if !c.blocked {
+		ch <- time.Time{}
+	}
+	return fakeTimer{clock: c, ch: ch}
+}
+
+type fakeTimer struct {
+	clock *fakeClock
+	ch    chan time.Time
+}
+
+func (t fakeTimer) C() <-chan time.Time { return t.ch }
+func (t fakeTimer) Stop() bool          { t.clock.stopped++; return true }
+
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "temporary" }
+func (temporaryError) Temporary() bool { return true }
+
+func TestDo(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	ctx := context.Background()
+
+	failing := func(errors ...error) (func(context.Context) error, *int) {
+		calls := new(int)
+		return func(context.Context) error {
+			*calls++
+			if *calls <= len(errors) {
+				return errors[*calls-1]
+			}
+			return nil
+		}, calls
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		clock := new(fakeClock)
+		fn, calls := failing(errs.Unavailable.New("1"), errs.Unavailable.New("2"))
+		err := Do(ctx, Policy{Classes: []*errs.Class{&errs.Unavailable}, Clock: clock}, fn)
+
+		assert(t, err == nil, err)
+		assert(t, *calls == 3)
+		assert(t, len(clock.delays) == 2)
+		assert(t, clock.delays[0] == 100*time.Millisecond, clock.delays)
+		assert(t, clock.delays[1] == 200*time.Millisecond, clock.delays)
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		first, second := errs.Unavailable.New("1"), errs.Unavailable.New("2")
+		fn, calls := failing(first, second, errs.Unavailable.New("3"))
+		err := Do(ctx, Policy{Attempts: 2, Classes: []*errs.Class{&errs.Unavailable}, Clock: new(fakeClock)}, fn)
+
+		assert(t, *calls == 2)
+		assert(t, err.Error() == "unavailable: 1; unavailable: 2", err)
+		assert(t, errs.Is(err, first) && errs.Is(err, second))
+	})
+
+	t.Run("Not Retryable", func(t *testing.T) {
+		clock := new(fakeClock)
+		invalid := errs.InvalidArgument.New("bad")
+		fn, calls := failing(errs.Unavailable.New("1"), invalid)
+		err := Do(ctx, Policy{Classes: []*errs.Class{&errs.Unavailable}, Clock: clock}, fn)
+
+		assert(t, *calls == 2)
+		assert(t, errs.InvalidArgument.Has(err) && errs.Unavailable.Has(err))
+
+		fn, calls = failing(invalid)
+		err = Do(ctx, Policy{Clock: clock}, fn)
+		assert(t, *calls == 1)
+		assert(t, err == invalid)
+	})
+
+	t.Run("Temporary", func(t *testing.T) {
+		fn, calls := failing(errs.Wrap(temporaryError{}))
+		err := Do(ctx, Policy{Temporary: true, Clock: new(fakeClock)}, fn)
+		assert(t, err == nil && *calls == 2)
+
+		fn, calls = failing(temporaryError{})
+		err = Do(ctx, Policy{Clock: new(fakeClock)}, fn)
+		assert(t, err != nil && *calls == 1)
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		fn, calls := failing()
+		err := Do(ctx, Policy{}, fn)
+		assert(t, *calls == 0)
+		assert(t, errors.Is(err, context.Canceled))
+
+		ctx, cancel = context.WithCancel(context.Background())
+		fn = func(context.Context) error { cancel(); return errs.Unavailable.New("t") }
+		err = Do(ctx, Policy{Classes: []*errs.Class{&errs.Unavailable}, Initial: time.Hour}, fn)
+		assert(t, errs.Unavailable.Has(err))
+		assert(t, errors.Is(err, context.Canceled))
+
+		blocked, stop := context.WithCancel(context.Background())
+		fn = func(context.Context) error { stop(); return errs.Unavailable.New("t") }
+		clock := &fakeClock{blocked: true}
+		err = Do(blocked, Policy{Classes: []*errs.Class{&errs.Unavailable}, Clock: clock}, fn)
+		assert(t, errors.Is(err, context.Canceled))
+		assert(t, clock.stopped == 1, clock.stopped)
+	})
+}
+
+func TestDelay(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	policy := Policy{
+		Initial:    time.Second,
+		Multiplier: 3,
+		Max:        5 * time.Second,
+		RetryAfter: map[*errs.Class]time.Duration{&errs.ResourceExhausted: time.Minute},
+	}
+
+	err := errs.Unavailable.New("t")
+	assert(t, policy.Delay(1, err) == time.Second)
+	assert(t, policy.Delay(2, err) == 3*time.Second)
+	assert(t, policy.Delay(3, err) == 5*time.Second)
+	assert(t, policy.Delay(100, err) == 5*time.Second)
+
+	assert(t, policy.Delay(1, errs.ResourceExhausted.New("t")) == time.Minute)
+	assert(t, policy.Delay(1, errs.Unavailable.Wrap(errs.ResourceExhausted.New("t"))) == time.Minute)
+
+	policy.Jitter = 0.5
+	policy.Rand = func() float64 { return 0.5 }
+	assert(t, policy.Delay(1, err) == 750*time.Millisecond, policy.Delay(1, err))
+
+	policy.Jitter = 3
+	policy.Rand = func() float64 { return 0.99 }
+	assert(t, policy.Delay(1, err) >= 0, policy.Delay(1, err))
+	assert(t, policy.Delay(1, err) == 10*time.Millisecond, policy.Delay(1, err))
+}
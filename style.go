@@ -0,0 +1,34 @@
+package errs
+
+// NameStyle controls where the name of a class is written in the messages of
+// its errors. It does not change which classes an error has.
+type NameStyle int
+
+// The styles for the names of classes.
+const (
+	// NamePrefix writes the name before the message, like "name: message".
+	// It is the default.
+	NamePrefix NameStyle = iota
+
+	// NameSuffix writes the name after the message, like "message (name)".
+	NameSuffix
+
+	// NameHidden leaves the name out of the message unless the error, or an
+	// error of this package wrapping it, is formatted with "%+v". It is
+	// useful for internal classes of errors whose messages are shown to
+	// users.
+	NameHidden
+)
+
+// SetNameStyle sets where the name of the class is written in the messages of
+// its errors.
+func (c *Class) SetNameStyle(style NameStyle) {
+	c.updateOptions(func(opts *classOptions) { opts.nameStyle = style })
+}
+
+// SetSeparator sets what is written between the name of the class and the
+// message when the name is written as a prefix. The default is ": ". A
+// Layout with a Separator overrides it when formatting with "%+v".
+func (c *Class) SetSeparator(sep string) {
+	c.updateOptions(func(opts *classOptions) { opts.separator = sep })
+}
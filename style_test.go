@@ -0,0 +1,61 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNameStyle(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+
+	hidden := Class("hidden")
+	hidden.SetNameStyle(NameHidden)
+
+	suffix := Class("suffix")
+	suffix.SetNameStyle(NameSuffix)
+
+	dashed := Class("dashed")
+	dashed.SetSeparator(" - ")
+
+	t.Run("Hidden", func(t *testing.T) {
+		err := hidden.New("t")
+		assert(t, err.Error() == "t", err.Error())
+		assert(t, fmt.Sprintf("%v", foo.Wrap(err)) == "foo: t")
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "hidden: t\n"))
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", foo.Wrap(err)), "foo: hidden: t\n"), fmt.Sprintf("%+v", foo.Wrap(err)))
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", foo.Wrap(Wrap(err))), "foo: hidden: t\n"))
+		assert(t, hidden.Has(foo.Wrap(err)))
+
+		name, ok := err.(Namer).Name()
+		assert(t, ok && name == "hidden")
+	})
+
+	t.Run("Suffix", func(t *testing.T) {
+		err := suffix.New("t")
+		assert(t, err.Error() == "t (suffix)", err.Error())
+		assert(t, foo.Wrap(err).Error() == "foo: t (suffix)")
+		assert(t, suffix.Wrap(foo.New("t")).Error() == "foo: t (suffix)")
+		assert(t, suffix.New("").Error() == "suffix")
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "t (suffix)\n"))
+	})
+
+	t.Run("Separator", func(t *testing.T) {
+		err := dashed.New("t")
+		assert(t, err.Error() == "dashed - t", err.Error())
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "dashed - t\n"))
+		assert(t, Unredacted(err) == "dashed - t")
+
+		defer SetLayout(FunctionLines)
+		SetLayout(Layout{Separator: " | "})
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "dashed | t\n"))
+		assert(t, err.Error() == "dashed - t", err.Error())
+	})
+}
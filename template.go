@@ -0,0 +1,19 @@
+package errs
+
+// Template returns the format string and arguments that the error was created
+// with by New or (*Class).New. If the error wraps multiple such errors, the
+// outermost one is used. It returns an empty format string and nil arguments
+// if there is no such error.
+//
+// The returned arguments are shared with the error and should not be
+// modified.
+func Template(err error) (format string, args []interface{}) {
+	IsFunc(err, func(err error) bool {
+		if m, ok := err.(*message); ok {
+			format, args = m.format, m.args
+			return true
+		}
+		return false
+	})
+	return format, args
+}
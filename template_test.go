@@ -0,0 +1,51 @@
+package errs
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	bar := Class("bar")
+
+	t.Run("New", func(t *testing.T) {
+		format, args := Template(New("user %d not found: %s", 42, "zeebo"))
+		assert(t, format == "user %d not found: %s", format)
+		assert(t, len(args) == 2, args)
+		assert(t, args[0] == 42, args)
+		assert(t, args[1] == "zeebo", args)
+	})
+
+	t.Run("Class", func(t *testing.T) {
+		format, args := Template(bar.Wrap(foo.New("user %d", 42)))
+		assert(t, format == "user %d", format)
+		assert(t, len(args) == 1 && args[0] == 42, args)
+	})
+
+	t.Run("Outermost", func(t *testing.T) {
+		format, args := Template(New("outer: %w", New("inner %d", 1)))
+		assert(t, format == "outer: %w", format)
+		assert(t, len(args) == 1, args)
+	})
+
+	t.Run("Not Created", func(t *testing.T) {
+		format, args := Template(foo.Wrap(io.EOF))
+		assert(t, format == "", format)
+		assert(t, args == nil, args)
+
+		format, args = Template(errors.New("t"))
+		assert(t, format == "" && args == nil)
+
+		format, args = Template(nil)
+		assert(t, format == "" && args == nil)
+	})
+}
@@ -0,0 +1,43 @@
+//go:build go1.21
+// +build go1.21
+
+package errs
+
+// TypedClass is a Class whose errors carry a payload of type T, like the
+// version number of a conflicting write. It behaves like the Class it embeds
+// for Has, Classes and formatting. It requires Go 1.21, the first version
+// where a build constraint lets a file use generics while the module declares
+// an older version of Go. It is declared like
+//
+//	var Conflict = &errs.TypedClass[int]{Class: "conflict"}
+type TypedClass[T any] struct {
+	Class
+}
+
+// typedPayload boxes the payload of a TypedClass so that it can be told apart
+// from no payload at all when T is an interface type.
+type typedPayload[T any] struct{ value T }
+
+// New constructs an error in this class carrying the payload, with the
+// message given by the format string and arguments.
+func (c *TypedClass[T]) New(payload T, format string, args ...interface{}) error {
+	m := newMessage(format, args)
+	m.payload = typedPayload[T]{value: payload}
+	return c.Class.create(3, m, nil)
+}
+
+// Payload returns the payload of the outermost error created by New of this
+// class anywhere in the chain of err, and whether there was one.
+func (c *TypedClass[T]) Payload(err error) (payload T, ok bool) {
+	IsFunc(err, func(err error) bool {
+		if errt, isErrorT := asErrorT(err); isErrorT && errt.class == &c.Class {
+			if m, isMessage := errt.err.(*message); isMessage {
+				var boxed typedPayload[T]
+				boxed, ok = m.payload.(typedPayload[T])
+				payload = boxed.value
+			}
+		}
+		return ok
+	})
+	return payload, ok
+}
@@ -0,0 +1,68 @@
+//go:build go1.21
+// +build go1.21
+
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTypedClass(t *testing.T) {
+	assert := func(t *testing.T, v bool, err ...interface{}) {
+		t.Helper()
+		if !v {
+			t.Fatal(err...)
+		}
+	}
+
+	foo := Class("foo")
+	conflict := &TypedClass[int]{Class: "conflict"}
+	other := &TypedClass[int]{Class: "other"}
+
+	t.Run("Payload", func(t *testing.T) {
+		err := foo.Wrap(conflict.New(7, "version %d is stale", 3))
+
+		version, ok := conflict.Payload(err)
+		assert(t, ok && version == 7, version, ok)
+
+		_, ok = other.Payload(err)
+		assert(t, !ok)
+
+		_, ok = conflict.Payload(conflict.Wrap(errors.New("t")))
+		assert(t, !ok)
+
+		_, ok = conflict.Payload(nil)
+		assert(t, !ok)
+	})
+
+	t.Run("Wrapped Argument", func(t *testing.T) {
+		err := foo.New("write: %w", conflict.New(7, "stale"))
+		version, ok := conflict.Payload(err)
+		assert(t, ok && version == 7, version, ok)
+	})
+
+	t.Run("Interface Payload", func(t *testing.T) {
+		typed := &TypedClass[error]{Class: "typed"}
+		payload, ok := typed.Payload(typed.New(nil, "t"))
+		assert(t, ok && payload == nil, payload, ok)
+	})
+
+	t.Run("Class", func(t *testing.T) {
+		err := foo.Wrap(conflict.New(7, "version %d is stale", 3))
+		assert(t, conflict.Has(err))
+		assert(t, !other.Has(err))
+		assert(t, errors.Is(err, conflict.Instance()))
+
+		classes := Classes(err)
+		assert(t, len(classes) == 2 && classes[1] == &conflict.Class, classes)
+
+		assert(t, err.Error() == "foo: conflict: version 3 is stale", err.Error())
+		assert(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "foo: conflict: version 3 is stale\n"))
+
+		format, args := Template(err)
+		assert(t, format == "version %d is stale" && len(args) == 1, format, args)
+	})
+}